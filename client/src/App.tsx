@@ -10,17 +10,25 @@ type NeighborData = {
 };
 
 type CategoryScores = {
-  Logic: number;
-  Ethics: number;
-  Aesthetics: number;
-  Postmodern: number;
+  logic: number;
+  ethics: number;
+  aesthetics: number;
+  postmodern: number;
 };
 
 type SubIndicators = {
-  Q13: number;
-  Q14: number;
-  Q15: number;
-  Q16: number;
+  agnostic: number;
+  deontology: number;
+  science: number;
+  analytic: number;
+};
+
+type AxisScore = {
+  code: string;
+  group: 'main' | 'sub';
+  strength: number;
+  balanced: boolean;
+  letter: string;
 };
 
 type PhiloLabel = {
@@ -29,6 +37,7 @@ type PhiloLabel = {
   full_label: string;
   category_scores: CategoryScores;
   sub_scores: SubIndicators;
+  axes?: AxisScore[];
 };
 
 type DataPoint = {
@@ -48,22 +57,8 @@ type Philosopher = {
   name: string;
   era: string;
   description: string;
-  answer_01: number;
-  answer_02: number;
-  answer_03: number;
-  answer_04: number;
-  answer_05: number;
-  answer_06: number;
-  answer_07: number;
-  answer_08: number;
-  answer_09: number;
-  answer_10: number;
-  answer_11: number;
-  answer_12: number;
-  answer_13: number;
-  answer_14: number;
-  answer_15: number;
-  answer_16: number;
+  questionnaire_version: number;
+  answers: number[];
   deleted: boolean;
   created_at: string;
   created_by?: string;
@@ -4,17 +4,17 @@ import CommonHeader from './CommonHeader';
 import styles from '../styles/History.module.scss';
 
 type CategoryScores = {
-  Logic: number;
-  Ethics: number;
-  Aesthetics: number;
-  Postmodern: number;
+  logic: number;
+  ethics: number;
+  aesthetics: number;
+  postmodern: number;
 };
 
 type SubIndicators = {
-  Q13: number;
-  Q14: number;
-  Q15: number;
-  Q16: number;
+  agnostic: number;
+  deontology: number;
+  science: number;
+  analytic: number;
 };
 
 type PhiloLabel = {
@@ -47,22 +47,8 @@ type Philosopher = {
   name: string;
   era: string;
   description: string;
-  answer_01: number;
-  answer_02: number;
-  answer_03: number;
-  answer_04: number;
-  answer_05: number;
-  answer_06: number;
-  answer_07: number;
-  answer_08: number;
-  answer_09: number;
-  answer_10: number;
-  answer_11: number;
-  answer_12: number;
-  answer_13: number;
-  answer_14: number;
-  answer_15: number;
-  answer_16: number;
+  questionnaire_version: number;
+  answers: number[];
   deleted: boolean;
   created_at: string;
   created_by?: string;
@@ -112,13 +98,7 @@ function HistoryPage({ onBackToWelcome, onBackToPrevious, onLogout, getAuthHeade
 
         const data = await response.json();
 
-        const answersArray = [
-          data.answer_01, data.answer_02, data.answer_03, data.answer_04,
-          data.answer_05, data.answer_06, data.answer_07, data.answer_08,
-          data.answer_09, data.answer_10, data.answer_11, data.answer_12,
-          data.answer_13, data.answer_14, data.answer_15, data.answer_16,
-        ];
-        setAnswers(answersArray);
+        setAnswers(data.answers);
 
         const statsResponse = await fetch(`${API_URL}/api/statistics/distribution/${data.id}`);
         if (statsResponse.ok) {
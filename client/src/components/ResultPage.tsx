@@ -8,17 +8,25 @@ type NeighborData = {
 };
 
 type CategoryScores = {
-  Logic: number;
-  Ethics: number;
-  Aesthetics: number;
-  Postmodern: number;
+  logic: number;
+  ethics: number;
+  aesthetics: number;
+  postmodern: number;
 };
 
 type SubIndicators = {
-  Q13: number;
-  Q14: number;
-  Q15: number;
-  Q16: number;
+  agnostic: number;
+  deontology: number;
+  science: number;
+  analytic: number;
+};
+
+type AxisScore = {
+  code: string;
+  group: 'main' | 'sub';
+  strength: number;
+  balanced: boolean;
+  letter: string;
 };
 
 type PhiloLabel = {
@@ -27,6 +35,7 @@ type PhiloLabel = {
   full_label: string;
   category_scores: CategoryScores;
   sub_scores: SubIndicators;
+  axes?: AxisScore[];
 };
 
 type DataPoint = {
@@ -46,22 +55,8 @@ type Philosopher = {
   name: string;
   era: string;
   description: string;
-  answer_01: number;
-  answer_02: number;
-  answer_03: number;
-  answer_04: number;
-  answer_05: number;
-  answer_06: number;
-  answer_07: number;
-  answer_08: number;
-  answer_09: number;
-  answer_10: number;
-  answer_11: number;
-  answer_12: number;
-  answer_13: number;
-  answer_14: number;
-  answer_15: number;
-  answer_16: number;
+  questionnaire_version: number;
+  answers: number[];
   deleted: boolean;
   created_at: string;
   created_by?: string;
@@ -88,6 +83,21 @@ type Props = {
   isAuthenticated?: boolean;
 };
 
+// どちらの極とも言えない軸（バランス型）を表す文字（サーバーのBalancedLetterと同じ）
+const BALANCED_LETTER = 'X';
+
+// グループ内のindex番目の軸がバランス型か（"X"を使わない質問票でも極への強さが小さければtrue）
+const isBalancedAxis = (philoLabel: PhiloLabel, group: AxisScore['group'], index: number): boolean =>
+  philoLabel.axes?.filter((axis) => axis.group === group)[index]?.balanced ?? false;
+
+// ラベルの1文字から極の名前を返す（バランス型の軸は正負どちらの極の名前にもしない）
+const poleName = (letter: string, balanced: boolean, positiveLetter: string, positiveName: string, negativeName: string): string => {
+  if (balanced || letter === BALANCED_LETTER) {
+    return 'バランス型';
+  }
+  return letter === positiveLetter ? positiveName : negativeName;
+};
+
 const ResultPage: React.FC<Props> = ({ answers, neighborData, philoLabel, categoryDistribution, closestPhilosopher, onSave, onSkip, showSaveOption = false, onBackToWelcome, onLogout, isAuthenticated = false }) => {
   return (
     <div style={{ maxWidth: '800px', margin: '0 auto', padding: '20px' }}>
@@ -126,37 +136,37 @@ const ResultPage: React.FC<Props> = ({ answers, neighborData, philoLabel, catego
             <div style={{ padding: '12px', backgroundColor: '#f5f5f5', borderRadius: '4px' }}>
               <div style={{ fontSize: '12px', color: '#666' }}>論理</div>
               <div style={{ fontSize: '20px', fontWeight: 'bold', marginTop: '4px' }}>
-                {philoLabel.main_label[0]} ({philoLabel.category_scores.Logic > 0 ? '+' : ''}{philoLabel.category_scores.Logic})
+                {philoLabel.main_label[0]} ({philoLabel.category_scores.logic > 0 ? '+' : ''}{philoLabel.category_scores.logic})
               </div>
               <div style={{ fontSize: '11px', color: '#999', marginTop: '4px' }}>
-                {philoLabel.main_label[0] === 'S' ? '構造志向' : '大きな物語志向'}
+                {poleName(philoLabel.main_label[0], isBalancedAxis(philoLabel, 'main', 0), 'S', '構造志向', '大きな物語志向')}
               </div>
             </div>
             <div style={{ padding: '12px', backgroundColor: '#f5f5f5', borderRadius: '4px' }}>
               <div style={{ fontSize: '12px', color: '#666' }}>倫理</div>
               <div style={{ fontSize: '20px', fontWeight: 'bold', marginTop: '4px' }}>
-                {philoLabel.main_label[1]} ({philoLabel.category_scores.Ethics > 0 ? '+' : ''}{philoLabel.category_scores.Ethics})
+                {philoLabel.main_label[1]} ({philoLabel.category_scores.ethics > 0 ? '+' : ''}{philoLabel.category_scores.ethics})
               </div>
               <div style={{ fontSize: '11px', color: '#999', marginTop: '4px' }}>
-                {philoLabel.main_label[1] === 'A' ? '行為論志向' : '徳論志向'}
+                {poleName(philoLabel.main_label[1], isBalancedAxis(philoLabel, 'main', 1), 'A', '行為論志向', '徳論志向')}
               </div>
             </div>
             <div style={{ padding: '12px', backgroundColor: '#f5f5f5', borderRadius: '4px' }}>
               <div style={{ fontSize: '12px', color: '#666' }}>美学</div>
               <div style={{ fontSize: '20px', fontWeight: 'bold', marginTop: '4px' }}>
-                {philoLabel.main_label[2]} ({philoLabel.category_scores.Aesthetics > 0 ? '+' : ''}{philoLabel.category_scores.Aesthetics})
+                {philoLabel.main_label[2]} ({philoLabel.category_scores.aesthetics > 0 ? '+' : ''}{philoLabel.category_scores.aesthetics})
               </div>
               <div style={{ fontSize: '11px', color: '#999', marginTop: '4px' }}>
-                {philoLabel.main_label[2] === 'O' ? '存在論志向' : '認識論志向'}
+                {poleName(philoLabel.main_label[2], isBalancedAxis(philoLabel, 'main', 2), 'O', '存在論志向', '認識論志向')}
               </div>
             </div>
             <div style={{ padding: '12px', backgroundColor: '#f5f5f5', borderRadius: '4px' }}>
               <div style={{ fontSize: '12px', color: '#666' }}>ポストモダン</div>
               <div style={{ fontSize: '20px', fontWeight: 'bold', marginTop: '4px' }}>
-                {philoLabel.main_label[3]} ({philoLabel.category_scores.Postmodern > 0 ? '+' : ''}{philoLabel.category_scores.Postmodern})
+                {philoLabel.main_label[3]} ({philoLabel.category_scores.postmodern > 0 ? '+' : ''}{philoLabel.category_scores.postmodern})
               </div>
               <div style={{ fontSize: '11px', color: '#999', marginTop: '4px' }}>
-                {philoLabel.main_label[3] === 'M' ? 'モダン志向' : 'ポストモダン志向'}
+                {poleName(philoLabel.main_label[3], isBalancedAxis(philoLabel, 'main', 3), 'M', 'モダン志向', 'ポストモダン志向')}
               </div>
             </div>
           </div>
@@ -174,7 +184,7 @@ const ResultPage: React.FC<Props> = ({ answers, neighborData, philoLabel, catego
                   {philoLabel.sub_label[0]}
                 </div>
                 <div style={{ marginTop: '4px' }}>
-                  {philoLabel.sub_label[0] === 'L' ? '論理重視' : '現象学的'}
+                  {poleName(philoLabel.sub_label[0], isBalancedAxis(philoLabel, 'sub', 0), 'L', '論理重視', '現象学的')}
                 </div>
               </div>
               <div style={{ fontSize: '12px', color: '#666' }}>
@@ -182,7 +192,7 @@ const ResultPage: React.FC<Props> = ({ answers, neighborData, philoLabel, catego
                   {philoLabel.sub_label[1]}
                 </div>
                 <div style={{ marginTop: '4px' }}>
-                  {philoLabel.sub_label[1] === 'D' ? '義務論的' : '功利主義的'}
+                  {poleName(philoLabel.sub_label[1], isBalancedAxis(philoLabel, 'sub', 1), 'D', '義務論的', '功利主義的')}
                 </div>
               </div>
               <div style={{ fontSize: '12px', color: '#666' }}>
@@ -190,7 +200,7 @@ const ResultPage: React.FC<Props> = ({ answers, neighborData, philoLabel, catego
                   {philoLabel.sub_label[2]}
                 </div>
                 <div style={{ marginTop: '4px' }}>
-                  {philoLabel.sub_label[2] === 'S' ? '科学的' : '人文的'}
+                  {poleName(philoLabel.sub_label[2], isBalancedAxis(philoLabel, 'sub', 2), 'S', '科学的', '人文的')}
                 </div>
               </div>
               <div style={{ fontSize: '12px', color: '#666' }}>
@@ -198,7 +208,7 @@ const ResultPage: React.FC<Props> = ({ answers, neighborData, philoLabel, catego
                   {philoLabel.sub_label[3]}
                 </div>
                 <div style={{ marginTop: '4px' }}>
-                  {philoLabel.sub_label[3] === 'A' ? '不可知論的' : '可知論的'}
+                  {poleName(philoLabel.sub_label[3], isBalancedAxis(philoLabel, 'sub', 3), 'A', '不可知論的', '可知論的')}
                 </div>
               </div>
             </div>
@@ -14,10 +14,10 @@ type CategoryData = {
 };
 
 type CategoryScores = {
-  Logic: number;
-  Ethics: number;
-  Aesthetics: number;
-  Postmodern: number;
+  logic: number;
+  ethics: number;
+  aesthetics: number;
+  postmodern: number;
 };
 
 type Props = {
@@ -49,28 +49,28 @@ const CategoryDistributionGrid: React.FC<Props> = ({ data, userScores }) => {
           <CategoryDistributionChart
             categoryName="論理"
             data={data.logic}
-            userScore={userScores.Logic}
+            userScore={userScores.logic}
           />
         </div>
         <div style={{ textAlign: 'center' }}>
           <CategoryDistributionChart
             categoryName="倫理"
             data={data.ethics}
-            userScore={userScores.Ethics}
+            userScore={userScores.ethics}
           />
         </div>
         <div style={{ textAlign: 'center' }}>
           <CategoryDistributionChart
             categoryName="美学"
             data={data.aesthetics}
-            userScore={userScores.Aesthetics}
+            userScore={userScores.aesthetics}
           />
         </div>
         <div style={{ textAlign: 'center' }}>
           <CategoryDistributionChart
             categoryName="ポストモダン"
             data={data.postmodern}
-            userScore={userScores.Postmodern}
+            userScore={userScores.postmodern}
           />
         </div>
       </div>
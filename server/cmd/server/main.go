@@ -7,6 +7,7 @@ import (
 	"github.com/HH19xx/philoCompass/internal/config"
 	"github.com/HH19xx/philoCompass/internal/handler"
 	"github.com/HH19xx/philoCompass/internal/middleware"
+	"github.com/HH19xx/philoCompass/internal/model"
 	"github.com/HH19xx/philoCompass/internal/repository"
 	"github.com/HH19xx/philoCompass/internal/service"
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,7 @@ func main() {
 
 	// マイグレーション実行（DB_TYPEを渡す）
 	if err := config.RunMigrations(db, cfg.DBType); err != nil {
-		log.Printf("Migration warning: %v", err)
+		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// シードデータ投入（DB_TYPEを渡す）
@@ -48,10 +49,21 @@ func main() {
 	// リポジトリの初期化
 	userRepo := repository.NewUserRepository(db)
 	answerRepo := repository.NewAnswerRepository(db)
+	answerDraftRepo := repository.NewAnswerDraftRepository(db)
 	philosopherRepo := repository.NewPhilosopherRepository(db)
+	questionnaireRepo := repository.NewQuestionnaireRepository(db)
+	labelProfileRepo := repository.NewLabelProfileRepository(db)
+
+	// 近傍検索用の回答のインデックスを作成（以降は回答の保存時に追加する）
+	answers, err := answerRepo.FindAnswers(model.AnswerFilter{IncludeFlagged: true})
+	if err != nil {
+		log.Fatalf("Failed to load answers: %v", err)
+	}
+	answerIndex := service.NewAnswerIndex(answers)
+	log.Printf("Loaded %d answers into the answer index", len(answers))
 
 	// ハンドラーの初期化
-	h := handler.NewHandler(userRepo, answerRepo, philosopherRepo, authService, googleOAuthConfig)
+	h := handler.NewHandler(userRepo, answerRepo, answerDraftRepo, philosopherRepo, questionnaireRepo, labelProfileRepo, answerIndex, authService, googleOAuthConfig)
 
 	// Ginルーターの設定
 	r := gin.Default()
@@ -65,15 +77,37 @@ func main() {
 		api.GET("/hello", h.HelloHandler)
 		api.POST("/register", h.RegisterHandler)
 		api.POST("/login", h.LoginHandler)
+		api.GET("/questionnaires/:version", h.GetQuestionnaireHandler)                                      // 質問票の取得（"latest"で最新版）
+		api.POST("/questionnaires/:version/next", h.NextQuestionHandler)                                    // 適応型出題の次の設問
+		api.GET("/labels", h.GetLabelsHandler)                                                              // ラベル解説の一覧
+		api.GET("/labels/:code", h.GetLabelHandler)                                                         // ラベル解説（例: "SVOP", "SVOP-ADSL"）
 		api.POST("/answers", h.CreateAnswerHandler)                                                         // 匿名での回答保存（統計用）
+		api.POST("/score", h.ScoreAnswersHandler)                                                           // 保存せずにスコアを計算（試算用）
 		api.GET("/statistics/distribution/:answer_id", h.GetNeighborDistributionByAnswerIDHandler)          // 特定回答の統計取得
 		api.GET("/statistics/category-distribution/:answer_id", h.GetCategoryDistributionByAnswerIDHandler) // カテゴリ別スコア分布取得
+		api.GET("/statistics/percentiles/:answer_id", h.GetPercentilesByAnswerIDHandler)                    // 各軸のパーセンタイル取得
+		api.GET("/statistics/labels", h.GetLabelStatisticsHandler)                                          // ラベルごとの回答数とラベルの珍しさ
+		api.GET("/statistics/trends", h.GetTrendsHandler)                                                   // 週ごと・月ごとの推移
+		api.GET("/statistics/questions", h.GetQuestionStatisticsHandler)                                    // 設問ごとの回答分布と設問間の相関
+		api.GET("/compare/:answer_a/:answer_b", h.CompareAnswersHandler)                                    // 2つの回答の比較
+		api.GET("/philosophers/matches/:answer_id", h.GetPhilosopherMatchesHandler)                         // 近い哲学者の順位
+		api.GET("/philosophers/opposite/:answer_id", h.GetPhilosophicalOppositeHandler)                     // 正反対の哲学者
 
 		// Google OAuth認証
 		api.GET("/auth/google", h.GoogleLoginHandler)             // Google認証ページへリダイレクト
 		api.GET("/auth/google/callback", h.GoogleCallbackHandler) // Google認証後のコールバック
 	}
 
+	// 回答の下書き（匿名・ログインユーザーの両方で利用可能）
+	draftAPI := r.Group("/api/answer-drafts")
+	draftAPI.Use(middleware.OptionalAuthMiddleware(authService))
+	{
+		draftAPI.POST("", h.CreateAnswerDraftHandler)                      // 下書きの作成
+		draftAPI.GET("/:draft_id", h.GetAnswerDraftHandler)                // 下書きの再開
+		draftAPI.PATCH("/:draft_id", h.UpdateAnswerDraftHandler)           // 1問ずつ回答を保存
+		draftAPI.POST("/:draft_id/finalize", h.FinalizeAnswerDraftHandler) // 下書きを確定して回答として保存
+	}
+
 	// 認証が必要なルーティング
 	authAPI := r.Group("/api")
 	authAPI.Use(middleware.AuthMiddleware(authService))
@@ -101,7 +135,7 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -12,6 +12,8 @@ import (
 
 // RunMigrations migrationsディレクトリ内の.up.sqlファイルを順番に実行
 // dbTypeに応じてディレクトリを切り替え（sqlite: migrations_sqlite, postgres: migrations_postgres）
+// 適用済みのマイグレーションはschema_migrationsテーブルに記録し、次回以降は実行しない
+// 各ファイルはトランザクション内で実行し、失敗した場合は途中までの変更を残さずにエラーを返す
 func RunMigrations(db *sql.DB, dbType string) error {
 	var migrationsPath string
 	if dbType == "sqlite" {
@@ -46,29 +48,145 @@ func RunMigrations(db *sql.DB, dbType string) error {
 
 	sort.Strings(migrationFiles)
 
+	// 適用済みのマイグレーションを取得
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	// 記録を始める前に作成したDBでは、それまでのマイグレーションを適用済みとして記録
+	if len(applied) == 0 {
+		untracked, err := tableExists(db, dbType, "answers")
+		if err != nil {
+			return err
+		}
+		if untracked {
+			if err := markBaselineApplied(db, migrationFiles, applied); err != nil {
+				return err
+			}
+		}
+	}
+
 	// 各マイグレーションファイルを実行
 	for _, file := range migrationFiles {
-		log.Printf("Running migration: %s", filepath.Base(file))
-		
+		name := filepath.Base(file)
+		if applied[name] {
+			continue
+		}
+		log.Printf("Running migration: %s", name)
+
 		content, err := os.ReadFile(file)
 		if err != nil {
 			return fmt.Errorf("failed to read migration file %s: %w", file, err)
 		}
 
-		_, err = db.Exec(string(content))
-		if err != nil {
-			// すでに存在するテーブルのエラーは無視
-			log.Printf("Migration %s: %v (continuing...)", filepath.Base(file), err)
-			continue
+		if err := applyMigration(db, name, string(content)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
 		}
-		
-		log.Printf("Migration %s completed successfully", filepath.Base(file))
+
+		log.Printf("Migration %s completed successfully", name)
 	}
 
 	log.Println("All migrations completed")
 	return nil
 }
 
+// appliedMigrations schema_migrationsテーブルを作成し、適用済みのマイグレーションのファイル名を返す
+func appliedMigrations(db *sql.DB) (map[string]bool, error) {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name       VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := db.Query("SELECT name FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[name] = true
+	}
+	return applied, rows.Err()
+}
+
+// lastUntrackedMigration 適用履歴の記録を始める前からあった最後のマイグレーションのバージョン
+const lastUntrackedMigration = "000017"
+
+// tableExists 指定したテーブルが存在するかを返す
+func tableExists(db *sql.DB, dbType, table string) (bool, error) {
+	var query string
+	if dbType == "sqlite" {
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1"
+	} else {
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+	}
+
+	var count int
+	if err := db.QueryRow(query, table).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check table %s: %w", table, err)
+	}
+	return count > 0, nil
+}
+
+// markBaselineApplied lastUntrackedMigrationまでのマイグレーションを実行せずに適用済みとして記録
+// 記録を始める前のDBでは、これらは毎回の起動時に実行済みのため
+func markBaselineApplied(db *sql.DB, migrationFiles []string, applied map[string]bool) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var names []string
+	for _, file := range migrationFiles {
+		name := filepath.Base(file)
+		if len(name) < len(lastUntrackedMigration) || name[:len(lastUntrackedMigration)] > lastUntrackedMigration {
+			continue
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (name) VALUES ($1)", name); err != nil {
+			return fmt.Errorf("failed to mark migration %s as applied: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		applied[name] = true
+	}
+	log.Printf("Existing database without migration history, marked %d migrations as applied", len(names))
+	return nil
+}
+
+// applyMigration マイグレーションを実行し、同じトランザクション内で適用済みとして記録
+func applyMigration(db *sql.DB, name, content string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(content); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (name) VALUES ($1)", name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 // RunSeeds seedsディレクトリ内のSQLファイルを実行
 // dbTypeに応じてディレクトリを切り替え（sqlite: migrations_sqlite/seeds, postgres: migrations_postgres/seeds）
 func RunSeeds(db *sql.DB, dbType string) error {
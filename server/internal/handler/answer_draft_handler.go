@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/HH19xx/philoCompass/internal/model"
+	"github.com/HH19xx/philoCompass/internal/repository"
+	"github.com/HH19xx/philoCompass/internal/service"
+)
+
+// CreateAnswerDraftRequest 下書き作成リクエストの構造体
+// QuestionnaireVersionを省略した場合は最新の質問票に対する下書きを作成する
+type CreateAnswerDraftRequest struct {
+	QuestionnaireVersion *int `json:"questionnaire_version"`
+}
+
+// UpdateAnswerDraftRequest 下書きの1問分の回答を保存するリクエストの構造体
+// Positionは設問の位置（1始まり）、Valueのnullは「スキップ / わからない」
+type UpdateAnswerDraftRequest struct {
+	Position int    `json:"position" binding:"required"`
+	Value    *int16 `json:"value"`
+}
+
+// CreateAnswerDraftHandler 回答の下書きを作成するハンドラー
+// 認証は任意（ログイン中の場合は下書きをユーザーに紐づける）
+func (h *Handler) CreateAnswerDraftHandler(c *gin.Context) {
+	var req CreateAnswerDraftRequest
+
+	// ボディは省略可能（空の場合は最新の質問票）
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	// 回答対象の質問票を取得
+	questionnaire, err := h.findQuestionnaire(req.QuestionnaireVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+	if questionnaire == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown questionnaire version"})
+		return
+	}
+
+	// 期限切れの下書きを掃除（失敗しても下書きの作成は続行する）
+	if _, err := h.answerDraftRepo.DeleteExpiredDrafts(); err != nil {
+		log.Printf("Failed to delete expired answer drafts: %v", err)
+	}
+
+	draft := &model.AnswerDraft{
+		UserID:               optionalUserID(c),
+		QuestionnaireVersion: questionnaire.Version,
+		Values:               make(model.AnswerVector, len(questionnaire.Questions)),
+	}
+
+	if err := h.answerDraftRepo.CreateDraft(draft); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create answer draft"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, draft)
+}
+
+// GetAnswerDraftHandler 下書きを取得するハンドラー（途中から再開する場合）
+func (h *Handler) GetAnswerDraftHandler(c *gin.Context) {
+	draft, ok := h.getAccessibleDraft(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// UpdateAnswerDraftHandler 下書きに1問分の回答を保存するハンドラー
+// 保存するたびに下書きの有効期限が延長される
+func (h *Handler) UpdateAnswerDraftHandler(c *gin.Context) {
+	var req UpdateAnswerDraftRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	draft, ok := h.getAccessibleDraft(c)
+	if !ok {
+		return
+	}
+
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(draft.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+
+	// 設問の位置と回答値のチェック
+	if req.Position < 1 || req.Position > len(draft.Values) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Position must be between 1 and %d", len(draft.Values)),
+		})
+		return
+	}
+	if req.Value != nil && !questionnaire.InScale(*req.Value) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Answer values must be between %d and %d", questionnaire.ScaleMin, questionnaire.ScaleMax),
+		})
+		return
+	}
+
+	// 他の設問への同時の保存を上書きしないよう、1問分だけを保存する
+	draft, err = h.answerDraftRepo.SetDraftAnswer(draft.ID, req.Position-1, req.Value)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Answer draft not found"})
+		case repository.ErrDraftConflict:
+			c.JSON(http.StatusConflict, gin.H{"error": "Answer draft is being updated concurrently, please retry"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save answer draft"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// FinalizeAnswerDraftRequest 下書きを確定するリクエストの構造体（ボディは省略可能）
+type FinalizeAnswerDraftRequest struct {
+	DeviceID *string `json:"device_id"` // 匿名回答者の端末ID
+	model.AnswerMetadata
+}
+
+// FinalizeAnswerDraftHandler 下書きを確定して回答として保存するハンドラー
+// CreateAnswerHandlerと同じ検証を行い、未回答のまま残った設問はスキップとして扱う
+// ボディには回答時間などの付帯情報（model.AnswerMetadata）と端末IDを任意で指定できる
+func (h *Handler) FinalizeAnswerDraftHandler(c *gin.Context) {
+	var req FinalizeAnswerDraftRequest
+
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	draft, ok := h.getAccessibleDraft(c)
+	if !ok {
+		return
+	}
+
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(draft.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+
+	if errBody := validateAnswers(questionnaire, draft.Values); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
+	}
+	if errBody := validateAnswerMetadata(questionnaire, req.AnswerMetadata); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
+	}
+	if errBody := validateDeviceID(req.DeviceID); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
+	}
+
+	// 匿名の下書きをログイン中に確定した場合は、確定したユーザーの回答とする
+	userID := draft.UserID
+	if userID == nil {
+		userID = optionalUserID(c)
+	}
+
+	answer := &model.Answer{
+		UserID:               userID,
+		DeviceID:             req.DeviceID,
+		QuestionnaireVersion: draft.QuestionnaireVersion,
+		Values:               draft.Values,
+		AnswerMetadata:       req.AnswerMetadata,
+	}
+	answer.Quality = service.AssessAnswerQuality(answer, questionnaire)
+
+	// 下書きの削除と回答の保存を同じトランザクションで行い、同じ下書きから回答が重複して作られないようにする
+	if err := h.answerDraftRepo.FinalizeDraft(draft, answer); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, gin.H{"error": "Answer draft has already been finalized or was modified"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save answers"})
+		return
+	}
+	h.answerIndex.Add(*answer)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Answers saved successfully",
+		"answer_id": answer.ID,
+		"skipped_count": draft.Values.SkippedCount(),
+	})
+}
+
+// getAccessibleDraft URLパラメータの下書きを取得し、リクエストしたユーザーが利用できるか確認する
+// ユーザーに紐づいた下書きは本人のみ、匿名の下書きはIDを知っていれば誰でも利用できる
+// 利用できない場合はエラーレスポンスを書き込み、falseを返す
+func (h *Handler) getAccessibleDraft(c *gin.Context) (*model.AnswerDraft, bool) {
+	draft, err := h.answerDraftRepo.GetDraftByID(c.Param("draft_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve answer draft"})
+		return nil, false
+	}
+	if draft == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Answer draft not found"})
+		return nil, false
+	}
+
+	if draft.UserID != nil {
+		userID := optionalUserID(c)
+		if userID == nil || *userID != *draft.UserID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return nil, false
+		}
+	}
+
+	return draft, true
+}
+
+// optionalUserID JWTからユーザーIDを取得（未ログインの場合はnil）
+func optionalUserID(c *gin.Context) *int {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+	userID := userIDInterface.(int)
+	return &userID
+}
@@ -1,15 +1,23 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/HH19xx/philoCompass/internal/model"
+	"github.com/HH19xx/philoCompass/internal/service"
 )
 
 // CreateAnswerRequest 回答作成リクエストの構造体
+// QuestionnaireVersionを省略した場合は最新の質問票に対する回答として扱う
+// Answersのnullは「スキップ / わからない」として未回答のまま保存する
+// 回答時間などの付帯情報と端末IDは任意
 type CreateAnswerRequest struct {
-	Answers []int16 `json:"answers" binding:"required"`
+	QuestionnaireVersion *int     `json:"questionnaire_version"`
+	Answers              []*int16 `json:"answers" binding:"required"`
+	DeviceID             *string  `json:"device_id"` // 匿名回答者の端末ID（クライアントが生成して保持する識別子）
+	model.AnswerMetadata
 }
 
 // CreateAnswerHandler 回答データを匿名で保存するハンドラー（統計用）
@@ -26,60 +34,135 @@ func (h *Handler) CreateAnswerHandler(c *gin.Context) {
 		return
 	}
 
-	// 回答数のチェック
-	if len(req.Answers) != 16 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid answers count",
-			"expected": 16,
-			"received": len(req.Answers),
-		})
+	// 回答対象の質問票を取得
+	questionnaire, err := h.findQuestionnaire(req.QuestionnaireVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+	if questionnaire == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown questionnaire version"})
 		return
 	}
 
-	// 各回答が-2~2の範囲内かチェック
-	for i, val := range req.Answers {
-		if val < -2 || val > 2 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Answer values must be between -2 and 2",
-				"index": i + 1,
-			})
-			return
-		}
+	// 回答が質問票に適合しているかチェック
+	values := model.AnswerVector(req.Answers)
+	if errBody := validateAnswers(questionnaire, values); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
+	}
+	if errBody := validateAnswerMetadata(questionnaire, req.AnswerMetadata); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
+	}
+	if errBody := validateDeviceID(req.DeviceID); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
 	}
 
 	// モデル構造体を作成（UserIDはnil = 匿名）
 	answer := &model.Answer{
-		UserID:   nil,
-		Answer01: req.Answers[0],
-		Answer02: req.Answers[1],
-		Answer03: req.Answers[2],
-		Answer04: req.Answers[3],
-		Answer05: req.Answers[4],
-		Answer06: req.Answers[5],
-		Answer07: req.Answers[6],
-		Answer08: req.Answers[7],
-		Answer09: req.Answers[8],
-		Answer10: req.Answers[9],
-		Answer11: req.Answers[10],
-		Answer12: req.Answers[11],
-		Answer13: req.Answers[12],
-		Answer14: req.Answers[13],
-		Answer15: req.Answers[14],
-		Answer16: req.Answers[15],
+		UserID:               nil,
+		DeviceID:             req.DeviceID,
+		QuestionnaireVersion: questionnaire.Version,
+		Values:               values,
+		AnswerMetadata:       req.AnswerMetadata,
 	}
+	// 不注意な回答かどうかを判定（印の付いた回答は既定で統計から除外される）
+	answer.Quality = service.AssessAnswerQuality(answer, questionnaire)
 
 	// データベースに保存
 	if err := h.answerRepo.CreateAnswer(answer); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save answers"})
 		return
 	}
+	h.answerIndex.Add(*answer)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Answers saved successfully",
 		"answer_id": answer.ID,
+		"skipped_count": values.SkippedCount(),
 	})
 }
 
+// findQuestionnaire 指定バージョンの質問票を取得（nilの場合は最新版）
+func (h *Handler) findQuestionnaire(version *int) (*model.Questionnaire, error) {
+	if version != nil {
+		return h.questionnaireRepo.GetQuestionnaireByVersion(*version)
+	}
+	return h.questionnaireRepo.GetLatestQuestionnaire()
+}
+
+// validateAnswers 回答が質問票に適合しているか検証
+// 不正な場合はエラーレスポンスのボディを、問題がなければnilを返す
+func validateAnswers(questionnaire *model.Questionnaire, values model.AnswerVector) gin.H {
+	// 回答数のチェック（質問票の設問数と一致すること）
+	if len(values) != len(questionnaire.Questions) {
+		return gin.H{
+			"error": "Invalid answers count",
+			"expected": len(questionnaire.Questions),
+			"received": len(values),
+		}
+	}
+
+	// 各回答が質問票のスケールの範囲内かチェック（スキップは除く）
+	for i, val := range values {
+		if val != nil && !questionnaire.InScale(*val) {
+			return gin.H{
+				"error": fmt.Sprintf("Answer values must be between %d and %d", questionnaire.ScaleMin, questionnaire.ScaleMax),
+				"index": i + 1,
+			}
+		}
+	}
+
+	// すべてスキップした回答はラベルも距離も計算できないため受け付けない
+	if values.SkippedCount() == len(values) {
+		return gin.H{"error": "At least one question must be answered"}
+	}
+
+	return nil
+}
+
+// validateAnswerMetadata 回答の付帯情報を検証
+// 不正な場合はエラーレスポンスのボディを、問題がなければnilを返す
+func validateAnswerMetadata(questionnaire *model.Questionnaire, metadata model.AnswerMetadata) gin.H {
+	// 設問ごとの回答時間は設問数と同じ長さであること
+	if metadata.ResponseTimesMs != nil {
+		if len(metadata.ResponseTimesMs) != len(questionnaire.Questions) {
+			return gin.H{
+				"error": "Invalid response_times_ms count",
+				"expected": len(questionnaire.Questions),
+				"received": len(metadata.ResponseTimesMs),
+			}
+		}
+		for i, ms := range metadata.ResponseTimesMs {
+			if ms != nil && *ms < 0 {
+				return gin.H{"error": "Response times must not be negative", "index": i + 1}
+			}
+		}
+	}
+
+	if metadata.DurationMs != nil && *metadata.DurationMs < 0 {
+		return gin.H{"error": "Duration must not be negative"}
+	}
+	if metadata.Locale != nil && len(*metadata.Locale) > 35 {
+		return gin.H{"error": "Locale is too long"}
+	}
+	if metadata.ClientVersion != nil && len(*metadata.ClientVersion) > 50 {
+		return gin.H{"error": "Client version is too long"}
+	}
+
+	return nil
+}
+
+// validateDeviceID 端末IDの長さをチェック（問題がなければnil）
+func validateDeviceID(deviceID *string) gin.H {
+	if deviceID != nil && (len(*deviceID) == 0 || len(*deviceID) > 64) {
+		return gin.H{"error": "Device ID must be between 1 and 64 characters"}
+	}
+	return nil
+}
+
 // LinkAnswerToUserHandler 匿名回答をユーザーに紐づけるハンドラー
 // 認証必須
 func (h *Handler) LinkAnswerToUserHandler(c *gin.Context) {
@@ -105,6 +188,7 @@ func (h *Handler) LinkAnswerToUserHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link answer to user"})
 		return
 	}
+	h.answerIndex.LinkToUser(req.AnswerID, userID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Answer linked to user successfully",
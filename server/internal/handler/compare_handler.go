@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/HH19xx/philoCompass/internal/service"
+)
+
+// CompareAnswersHandler 2つの回答を比較するハンドラー（認証不要）
+// 距離・設問ごとの差・軸ごとのスコアの差・異なるラベルの文字を返す
+func (h *Handler) CompareAnswersHandler(c *gin.Context) {
+	// パスパラメータから回答IDを取得
+	answerIDA, errA := strconv.Atoi(c.Param("answer_a"))
+	answerIDB, errB := strconv.Atoi(c.Param("answer_b"))
+	if errA != nil || errB != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid answer_id"})
+		return
+	}
+
+	// 比較する回答を取得
+	answerA, err := h.answerRepo.GetAnswerByID(answerIDA)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve answer"})
+		return
+	}
+	answerB, err := h.answerRepo.GetAnswerByID(answerIDB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve answer"})
+		return
+	}
+	if answerA == nil || answerB == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Answer not found"})
+		return
+	}
+
+	// 設問数が異なる回答同士は比較できない
+	if answerA.QuestionnaireVersion != answerB.QuestionnaireVersion {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Answers are for different questionnaire versions"})
+		return
+	}
+
+	// 回答時の質問票を取得
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(answerA.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"answer_a":   answerA,
+		"answer_b":   answerB,
+		"comparison": service.CompareAnswers(answerA, answerB, questionnaire),
+	})
+}
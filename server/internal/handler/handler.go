@@ -11,16 +11,24 @@ import (
 type Handler struct {
 	userRepo          repository.UserRepository
 	answerRepo        repository.AnswerRepository
+	answerDraftRepo   repository.AnswerDraftRepository
 	philosopherRepo   repository.PhilosopherRepository
+	questionnaireRepo repository.QuestionnaireRepository
+	labelProfileRepo  repository.LabelProfileRepository
+	answerIndex       *service.AnswerIndex
 	authService       *service.AuthService
 	googleOAuthConfig *GoogleOAuthConfig
 }
 
-func NewHandler(userRepo repository.UserRepository, answerRepo repository.AnswerRepository, philosopherRepo repository.PhilosopherRepository, authService *service.AuthService, googleOAuthConfig *GoogleOAuthConfig) *Handler {
+func NewHandler(userRepo repository.UserRepository, answerRepo repository.AnswerRepository, answerDraftRepo repository.AnswerDraftRepository, philosopherRepo repository.PhilosopherRepository, questionnaireRepo repository.QuestionnaireRepository, labelProfileRepo repository.LabelProfileRepository, answerIndex *service.AnswerIndex, authService *service.AuthService, googleOAuthConfig *GoogleOAuthConfig) *Handler {
 	return &Handler{
 		userRepo:          userRepo,
 		answerRepo:        answerRepo,
+		answerDraftRepo:   answerDraftRepo,
 		philosopherRepo:   philosopherRepo,
+		questionnaireRepo: questionnaireRepo,
+		labelProfileRepo:  labelProfileRepo,
+		answerIndex:       answerIndex,
 		authService:       authService,
 		googleOAuthConfig: googleOAuthConfig,
 	}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/HH19xx/philoCompass/internal/model"
+	"github.com/HH19xx/philoCompass/internal/service"
+)
+
+// GetLabelsHandler すべてのメインラベル・サブラベルの解説を取得（認証不要）
+// クエリパラメータquestionnaire_versionを省略した場合は最新の質問票のラベルを返す
+func (h *Handler) GetLabelsHandler(c *gin.Context) {
+	catalog, questionnaire, ok := h.loadLabelCatalog(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questionnaire_version": questionnaire.Version,
+		"main":                  catalog.Entries(model.AxisGroupMain),
+		"sub":                   catalog.Entries(model.AxisGroupSub),
+	})
+}
+
+// GetLabelHandler 指定したラベルの解説を取得（認証不要）
+// コードはメインラベル（例: "SVOP"）、サブラベル（例: "ADSL"）、またはその両方（例: "SVOP-ADSL"）
+func (h *Handler) GetLabelHandler(c *gin.Context) {
+	catalog, questionnaire, ok := h.loadLabelCatalog(c)
+	if !ok {
+		return
+	}
+
+	code := strings.ToUpper(c.Param("code"))
+	var mainEntry, subEntry *service.LabelEntry
+	if mainCode, subCode, found := strings.Cut(code, "-"); found {
+		mainEntry = catalog.Entry(model.AxisGroupMain, mainCode)
+		subEntry = catalog.Entry(model.AxisGroupSub, subCode)
+		if mainEntry == nil || subEntry == nil {
+			mainEntry, subEntry = nil, nil
+		}
+	} else if mainEntry = catalog.Entry(model.AxisGroupMain, code); mainEntry == nil {
+		subEntry = catalog.Entry(model.AxisGroupSub, code)
+	}
+
+	if mainEntry == nil && subEntry == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Label not found"})
+		return
+	}
+
+	response := gin.H{
+		"questionnaire_version": questionnaire.Version,
+		"code":                  code,
+	}
+	if mainEntry != nil {
+		response["main"] = mainEntry
+	}
+	if subEntry != nil {
+		response["sub"] = subEntry
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// loadLabelCatalog クエリパラメータの質問票のラベル解説の一覧を作成
+// 作成できない場合はエラーレスポンスを書き込み、falseを返す
+func (h *Handler) loadLabelCatalog(c *gin.Context) (*service.LabelCatalog, *model.Questionnaire, bool) {
+	var version *int
+	if versionStr, ok := c.GetQuery("questionnaire_version"); ok {
+		v, err := strconv.Atoi(versionStr)
+		if err != nil || v <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid questionnaire_version"})
+			return nil, nil, false
+		}
+		version = &v
+	}
+
+	questionnaire, err := h.findQuestionnaire(version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return nil, nil, false
+	}
+	if questionnaire == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Questionnaire not found"})
+		return nil, nil, false
+	}
+
+	profiles, err := h.labelProfileRepo.GetLabelProfilesByQuestionnaireVersion(questionnaire.Version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve label profiles"})
+		return nil, nil, false
+	}
+
+	// 例となる哲学者は任意のため、取得できなくても哲学者なしで一覧を返す
+	philosophers, err := h.philosopherRepo.GetPhilosophersByQuestionnaireVersion(questionnaire.Version)
+	if err != nil {
+		log.Printf("Failed to retrieve philosophers for label catalog: %v", err)
+		philosophers = nil
+	}
+
+	return service.NewLabelCatalog(questionnaire, profiles, philosophers), questionnaire, true
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/HH19xx/philoCompass/internal/model"
+	"github.com/HH19xx/philoCompass/internal/service"
+)
+
+// 近い哲学者の一覧で返す人数
+const (
+	defaultPhilosopherMatches = 5
+	maxPhilosopherMatches     = 50
+)
+
+// GetPhilosopherMatchesHandler 指定した回答に近い哲学者を順位付きで取得（認証不要）
+// クエリパラメータkで人数、metricで距離指標を指定できる（既定は5人、重み付きユークリッド距離）
+func (h *Handler) GetPhilosopherMatchesHandler(c *gin.Context) {
+	// パスパラメータから回答IDを取得
+	answerID, err := strconv.Atoi(c.Param("answer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid answer_id"})
+		return
+	}
+
+	k := defaultPhilosopherMatches
+	if kStr, ok := c.GetQuery("k"); ok {
+		k, err = strconv.Atoi(kStr)
+		if err != nil || k < 1 || k > maxPhilosopherMatches {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid k parameter"})
+			return
+		}
+	}
+
+	target, ok := h.loadPhilosopherComparison(c, answerID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, service.FindPhilosopherMatches(target.answer, target.philosophers, target.metric, k))
+}
+
+// GetPhilosophicalOppositeHandler 指定した回答と正反対の哲学者を取得（認証不要）
+// 最も遠い哲学者と、すべての回答を反転した回答に最も近い哲学者を返す
+// クエリパラメータmetricで距離指標を指定できる（既定は重み付きユークリッド距離）
+func (h *Handler) GetPhilosophicalOppositeHandler(c *gin.Context) {
+	// パスパラメータから回答IDを取得
+	answerID, err := strconv.Atoi(c.Param("answer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid answer_id"})
+		return
+	}
+
+	target, ok := h.loadPhilosopherComparison(c, answerID)
+	if !ok {
+		return
+	}
+
+	opposite := service.FindPhilosophicalOpposite(target.answer, target.philosophers, target.questionnaire, target.metric)
+	c.JSON(http.StatusOK, gin.H{
+		"metric":   target.metric.Name(),
+		"farthest": opposite.Farthest,
+		"antipode": opposite.Antipode,
+	})
+}
+
+// philosopherComparison 回答と哲学者を比較するために必要なデータ
+type philosopherComparison struct {
+	answer        *model.Answer
+	questionnaire *model.Questionnaire
+	philosophers  []model.Philosopher
+	metric        service.DistanceMetric
+}
+
+// loadPhilosopherComparison 回答と、回答時の質問票・同じ質問票で回答された哲学者・クエリパラメータmetricの距離指標を取得
+// 取得できない場合はエラーレスポンスを書き込み、falseを返す
+func (h *Handler) loadPhilosopherComparison(c *gin.Context, answerID int) (*philosopherComparison, bool) {
+	// 指定された回答を取得
+	answer, err := h.answerRepo.GetAnswerByID(answerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve answer"})
+		return nil, false
+	}
+	if answer == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Answer not found"})
+		return nil, false
+	}
+
+	// 回答時の質問票を取得
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(answer.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return nil, false
+	}
+
+	// 同じ質問票で回答された哲学者のみを比較する
+	philosophers, err := h.philosopherRepo.GetPhilosophersByQuestionnaireVersion(answer.QuestionnaireVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve philosophers"})
+		return nil, false
+	}
+
+	// マハラノビス距離の共分散は、同じ質問票に対する回答の母集団から推定する
+	metric, err := h.parseDistanceMetric(c, questionnaire, model.AnswerFilter{QuestionnaireVersion: &answer.QuestionnaireVersion})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric parameter", "details": err.Error()})
+		return nil, false
+	}
+
+	return &philosopherComparison{
+		answer:        answer,
+		questionnaire: questionnaire,
+		philosophers:  philosophers,
+		metric:        metric,
+	}, true
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/HH19xx/philoCompass/internal/model"
+	"github.com/HH19xx/philoCompass/internal/service"
+)
+
+// NextQuestionRequest 適応型出題で次の設問を求めるリクエストの構造体
+// Answersは設問順の回答（nullはまだ出題していない設問、省略した末尾も未出題として扱う）
+// Skippedは「スキップ / わからない」と回答した設問の位置（1始まり）
+type NextQuestionRequest struct {
+	Answers []*int16 `json:"answers"`
+	Skipped []int    `json:"skipped"`
+}
+
+// GetQuestionnaireHandler 指定バージョンの質問票を取得（認証不要）
+// バージョンに"latest"を指定すると最新の質問票を返す
+func (h *Handler) GetQuestionnaireHandler(c *gin.Context) {
+	questionnaire, ok := h.getQuestionnaireFromParam(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, questionnaire)
+}
+
+// NextQuestionHandler 適応型出題で次に回答すべき設問を返すハンドラー（認証不要）
+// 残りの設問に回答してもラベルが変わらなくなった時点でdoneをtrueにして出題を終える
+// 確定した回答は、出題されなかった設問をnullにしてPOST /api/answersで保存する
+func (h *Handler) NextQuestionHandler(c *gin.Context) {
+	var req NextQuestionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	questionnaire, ok := h.getQuestionnaireFromParam(c)
+	if !ok {
+		return
+	}
+
+	if len(req.Answers) > len(questionnaire.Questions) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid answers count",
+			"expected": len(questionnaire.Questions),
+			"received": len(req.Answers),
+		})
+		return
+	}
+
+	// 省略された末尾の設問は未出題として扱う
+	vector := make(model.AnswerVector, len(questionnaire.Questions))
+	copy(vector, req.Answers)
+	for i, val := range vector {
+		if val != nil && !questionnaire.InScale(*val) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Answer values must be between %d and %d", questionnaire.ScaleMin, questionnaire.ScaleMax),
+				"index": i + 1,
+			})
+			return
+		}
+	}
+
+	skipped := make(map[int]bool, len(req.Skipped))
+	for _, position := range req.Skipped {
+		if position < 1 || position > len(questionnaire.Questions) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("Position must be between 1 and %d", len(questionnaire.Questions)),
+			})
+			return
+		}
+		skipped[position-1] = true
+	}
+
+	c.JSON(http.StatusOK, service.NextAdaptiveQuestion(vector, skipped, questionnaire))
+}
+
+// getQuestionnaireFromParam URLパラメータのバージョン（"latest"で最新版）の質問票を取得
+// 取得できない場合はエラーレスポンスを書き込み、falseを返す
+func (h *Handler) getQuestionnaireFromParam(c *gin.Context) (*model.Questionnaire, bool) {
+	versionStr := c.Param("version")
+
+	var questionnaire *model.Questionnaire
+	var err error
+	if versionStr == "latest" {
+		questionnaire, err = h.questionnaireRepo.GetLatestQuestionnaire()
+	} else {
+		version, convErr := strconv.Atoi(versionStr)
+		if convErr != nil || version <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+			return nil, false
+		}
+		questionnaire, err = h.questionnaireRepo.GetQuestionnaireByVersion(version)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return nil, false
+	}
+	if questionnaire == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Questionnaire not found"})
+		return nil, false
+	}
+
+	return questionnaire, true
+}
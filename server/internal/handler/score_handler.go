@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/HH19xx/philoCompass/internal/model"
+	"github.com/HH19xx/philoCompass/internal/service"
+)
+
+// ScoreRequest 保存せずにスコアを計算するリクエストの構造体
+// QuestionnaireVersionを省略した場合は最新の質問票に対する回答として扱う
+// Answersのnullは「スキップ / わからない」
+type ScoreRequest struct {
+	QuestionnaireVersion *int     `json:"questionnaire_version"`
+	Answers              []*int16 `json:"answers" binding:"required"`
+}
+
+// ScoreAnswersHandler 回答を保存せずにラベル・最近傍の哲学者・近傍の回答数分布を計算するハンドラー（認証不要）
+// 「この回答を変えたらどうなるか」の試算や採点ルールの結合テストに使う（統計の母集団には影響しない）
+// クエリパラメータで統計の母集団の絞り込み条件、距離指標（metric）、近傍ユーザー数の半径（radii / bins）を指定できる
+func (h *Handler) ScoreAnswersHandler(c *gin.Context) {
+	var req ScoreRequest
+
+	// リクエストボディをバインド
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// 回答対象の質問票を取得
+	questionnaire, err := h.findQuestionnaire(req.QuestionnaireVersion)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+	if questionnaire == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown questionnaire version"})
+		return
+	}
+
+	// 回答が質問票に適合しているかチェック
+	values := model.AnswerVector(req.Answers)
+	if errBody := validateAnswers(questionnaire, values); errBody != nil {
+		c.JSON(http.StatusBadRequest, errBody)
+		return
+	}
+
+	answer := &model.Answer{
+		QuestionnaireVersion: questionnaire.Version,
+		Values:               values,
+	}
+
+	// 同じ質問票に対する回答のうち、クエリパラメータの絞り込み条件に一致するものを母集団とする
+	filter, err := parseAnswerFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameter", "details": err.Error()})
+		return
+	}
+	filter.QuestionnaireVersion = &questionnaire.Version
+
+	// クエリパラメータで指定された距離指標で計算（近傍の回答数と最近傍の哲学者で共通）
+	metric, err := h.parseDistanceMetric(c, questionnaire, filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric parameter", "details": err.Error()})
+		return
+	}
+
+	// 半径の一覧（クエリパラメータradii / binsで指定、既定は距離指標の最大の距離に対する割合）とk番目に近い回答者のk
+	radii, err := parseNeighborRadii(c, metric)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radii parameter", "details": err.Error()})
+		return
+	}
+	k, err := parseNearestK(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid nearest_k parameter", "details": err.Error()})
+		return
+	}
+	// 保存していない回答は母集団に含まれないため、除外する回答はない
+	neighbors := h.answerIndex.Neighbors(values, filter, metric, radii, k, nil)
+
+	// 最近傍哲学者を検索（同じ質問票で回答された哲学者のみ）
+	// 哲学者との照合は任意のため、取得できなくてもスコアは返す（closest_philosopherはnull、philosopher_matchesは空）
+	philosophers, err := h.philosopherRepo.GetPhilosophersByQuestionnaireVersion(questionnaire.Version)
+	if err != nil {
+		log.Printf("Failed to retrieve philosophers for scoring: %v", err)
+		philosophers = nil
+	}
+
+	philoLabel := service.CalculatePhiloLabel(answer, questionnaire)
+
+	c.JSON(http.StatusOK, gin.H{
+		"questionnaire_version": questionnaire.Version,
+		"metric":                metric.Name(),
+		"label":                 philoLabel,
+		"category_scores":       philoLabel.Category,
+		"closest_philosopher":   service.FindClosestPhilosopher(answer, philosophers, questionnaire, metric),
+		"philosopher_matches":   service.FindPhilosopherMatches(answer, philosophers, metric, defaultPhilosopherMatches),
+		"distribution":          neighbors.Distribution,
+		"rarity":                neighbors.Rarity,
+		"skipped_count":         values.SkippedCount(),
+	})
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/HH19xx/philoCompass/internal/model"
@@ -11,12 +16,15 @@ import (
 
 // GetNeighborsHandler 指定半径内の近傍ユーザー数を取得
 func (h *Handler) GetNeighborsHandler(c *gin.Context) {
-	// クエリパラメータから半径を取得
-	radiusStr := c.DefaultQuery("radius", "3.0")
-	radius, err := strconv.ParseFloat(radiusStr, 64)
-	if err != nil || radius < 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radius parameter"})
-		return
+	// クエリパラメータから半径を取得（省略した場合は距離指標の最大の距離のdefaultNeighborRadiusFraction倍）
+	var radius *float64
+	if radiusStr, ok := c.GetQuery("radius"); ok {
+		r, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || r < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radius parameter"})
+			return
+		}
+		radius = &r
 	}
 
 	// JWTからユーザーIDを取得
@@ -38,21 +46,39 @@ func (h *Handler) GetNeighborsHandler(c *gin.Context) {
 		return
 	}
 
-	// すべての回答を取得
-	allAnswers, err := h.answerRepo.GetAllAnswers()
+	// 同じ質問票に対する回答のうち、クエリパラメータの絞り込み条件に一致するものを母集団とする
+	filter, err := parseAnswerFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve all answers"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameter", "details": err.Error()})
+		return
+	}
+	filter.QuestionnaireVersion = &userAnswer.QuestionnaireVersion
+
+	// 回答時の質問票を取得
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(userAnswer.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
 		return
 	}
 
-	// 距離計算サービスを使用
-	distanceService := service.NewDistanceService()
+	// クエリパラメータで指定された距離指標で、回答のインデックスから数える
+	metric, err := h.parseDistanceMetric(c, questionnaire, filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric parameter", "details": err.Error()})
+		return
+	}
+	if radius == nil {
+		r := metric.MaxDistance() * defaultNeighborRadiusFraction
+		radius = &r
+	}
 	targetVector := userAnswer.ToVector()
-	count := distanceService.CountNeighbors(targetVector, allAnswers, radius)
+	neighbors := h.answerIndex.Neighbors(targetVector, filter, metric, []float64{*radius}, 1, &userAnswer.ID)
 
 	c.JSON(http.StatusOK, gin.H{
-		"radius": radius,
-		"count":  count,
+		"metric":   metric.Name(),
+		"radius":   *radius,
+		"count":    neighbors.Distribution[0].Count,
+		"fraction": neighbors.Distribution[0].Fraction,
 	})
 }
 
@@ -77,21 +103,46 @@ func (h *Handler) GetNeighborDistributionHandler(c *gin.Context) {
 		return
 	}
 
-	// すべての回答を取得
-	allAnswers, err := h.answerRepo.GetAllAnswers()
+	// 同じ質問票に対する回答のうち、クエリパラメータの絞り込み条件に一致するものを母集団とする
+	filter, err := parseAnswerFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve all answers"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameter", "details": err.Error()})
 		return
 	}
+	filter.QuestionnaireVersion = &userAnswer.QuestionnaireVersion
 
-	// 複数の半径で計算（1, 2, 3, 5, 10）
-	radii := []float64{1.0, 2.0, 3.0, 5.0, 10.0}
-	distanceService := service.NewDistanceService()
+	// 回答時の質問票を取得
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(userAnswer.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+
+	// クエリパラメータで指定された距離指標で、回答のインデックスから数える
+	metric, err := h.parseDistanceMetric(c, questionnaire, filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric parameter", "details": err.Error()})
+		return
+	}
+
+	// 半径の一覧（クエリパラメータradii / binsで指定、既定は距離指標の最大の距離に対する割合）とk番目に近い回答者のk
+	radii, err := parseNeighborRadii(c, metric)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radii parameter", "details": err.Error()})
+		return
+	}
+	k, err := parseNearestK(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid nearest_k parameter", "details": err.Error()})
+		return
+	}
 	targetVector := userAnswer.ToVector()
-	distribution := distanceService.GetNeighborDistribution(targetVector, allAnswers, radii)
+	neighbors := h.answerIndex.Neighbors(targetVector, filter, metric, radii, k, &userAnswer.ID)
 
 	c.JSON(http.StatusOK, gin.H{
-		"distribution": distribution,
+		"metric":       metric.Name(),
+		"distribution": neighbors.Distribution,
+		"rarity":       neighbors.Rarity,
 	})
 }
 
@@ -116,35 +167,62 @@ func (h *Handler) GetNeighborDistributionByAnswerIDHandler(c *gin.Context) {
 		return
 	}
 
-	// すべての回答を取得
-	allAnswers, err := h.answerRepo.GetAllAnswers()
+	// 同じ質問票に対する回答のうち、クエリパラメータの絞り込み条件に一致するものを母集団とする
+	filter, err := parseAnswerFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve all answers"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameter", "details": err.Error()})
 		return
 	}
+	filter.QuestionnaireVersion = &answer.QuestionnaireVersion
 
-	// 複数の半径で計算（1, 2, 3, 5, 10）
-	radii := []float64{1.0, 2.0, 3.0, 5.0, 10.0}
-	distanceService := service.NewDistanceService()
+	// 回答時の質問票を取得
+	questionnaire, err := h.questionnaireRepo.GetQuestionnaireByVersion(answer.QuestionnaireVersion)
+	if err != nil || questionnaire == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return
+	}
+
+	// クエリパラメータで指定された距離指標で計算（近傍の回答数と最近傍の哲学者で共通）
+	metric, err := h.parseDistanceMetric(c, questionnaire, filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric parameter", "details": err.Error()})
+		return
+	}
+
+	// 半径の一覧（クエリパラメータradii / binsで指定、既定は距離指標の最大の距離に対する割合）とk番目に近い回答者のk
+	radii, err := parseNeighborRadii(c, metric)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid radii parameter", "details": err.Error()})
+		return
+	}
+	k, err := parseNearestK(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid nearest_k parameter", "details": err.Error()})
+		return
+	}
 	targetVector := answer.ToVector()
-	distribution := distanceService.GetNeighborDistribution(targetVector, allAnswers, radii)
+	neighbors := h.answerIndex.Neighbors(targetVector, filter, metric, radii, k, &answer.ID)
 
 	// 哲学ラベルを計算
-	philoLabel := service.CalculatePhiloLabel(answer)
+	philoLabel := service.CalculatePhiloLabel(answer, questionnaire)
 
-	// 最近傍哲学者を検索
-	philosophers, err := h.philosopherRepo.GetAllPhilosophers()
+	// 最近傍哲学者を検索（同じ質問票で回答された哲学者のみ）
+	philosophers, err := h.philosopherRepo.GetPhilosophersByQuestionnaireVersion(answer.QuestionnaireVersion)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve philosophers"})
 		return
 	}
-	closestPhilosopher := service.FindClosestPhilosopher(answer, philosophers)
+	closestPhilosopher := service.FindClosestPhilosopher(answer, philosophers, questionnaire, metric)
+	philosopherMatches := service.FindPhilosopherMatches(answer, philosophers, metric, defaultPhilosopherMatches)
 
 	c.JSON(http.StatusOK, gin.H{
-		"distribution":         distribution,
+		"metric":               metric.Name(),
+		"distribution":         neighbors.Distribution,
+		"rarity":               neighbors.Rarity,
 		"answer":               answer,
 		"label":                philoLabel,
 		"closest_philosopher":  closestPhilosopher,
+		"philosopher_matches":  philosopherMatches,
 	})
 }
 
@@ -169,21 +247,371 @@ func (h *Handler) GetCategoryDistributionByAnswerIDHandler(c *gin.Context) {
 		return
 	}
 
-	// すべての回答を取得
-	allAnswers, err := h.answerRepo.GetAllAnswers()
+	// 回答時の質問票と、同じ質問票に対する回答のうち絞り込み条件に一致するものを取得
+	questionnaire, population, ok := h.loadPopulation(c, &answer.QuestionnaireVersion)
+	if !ok {
+		return
+	}
+
+	// カテゴリ別スコア分布を計算
+	distributions := service.CalculateCategoryDistributions(population, questionnaire)
+
+	c.JSON(http.StatusOK, distributions)
+}
+
+// GetPercentilesByAnswerIDHandler 指定した回答の各軸スコアの母集団内でのパーセンタイルを取得（認証不要）
+func (h *Handler) GetPercentilesByAnswerIDHandler(c *gin.Context) {
+	// パスパラメータから回答IDを取得
+	answerIDStr := c.Param("answer_id")
+	answerID, err := strconv.Atoi(answerIDStr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve all answers"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid answer_id"})
+		return
+	}
+
+	// 指定された回答を取得
+	answer, err := h.answerRepo.GetAnswerByID(answerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve answer"})
+		return
+	}
+	if answer == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Answer not found"})
 		return
 	}
 
-	// []model.Answerを[]*model.Answerに変換
-	var answerPointers []*model.Answer
-	for i := range allAnswers {
-		answerPointers = append(answerPointers, &allAnswers[i])
+	// 回答時の質問票と、同じ質問票に対する回答のうち絞り込み条件に一致するものを取得
+	questionnaire, population, ok := h.loadPopulation(c, &answer.QuestionnaireVersion)
+	if !ok {
+		return
 	}
 
-	// カテゴリ別スコア分布を計算
-	distributions := service.CalculateCategoryDistributions(answerPointers)
+	// カテゴリ別スコア分布からパーセンタイルを計算
+	distributions := service.CalculateCategoryDistributions(population, questionnaire)
+	philoLabel := service.CalculatePhiloLabel(answer, questionnaire)
 
-	c.JSON(http.StatusOK, distributions)
+	c.JSON(http.StatusOK, gin.H{
+		"label":       philoLabel,
+		"percentiles": service.CalculatePercentileRanks(philoLabel, distributions, questionnaire),
+	})
+}
+
+// GetLabelStatisticsHandler ラベルごとの回答数を取得（認証不要）
+// クエリパラメータanswer_idを指定した場合はその回答の質問票で集計し、回答のラベルの珍しさも返す
+// 指定しない場合はquestionnaire_version（省略時は最新）の質問票で集計する
+func (h *Handler) GetLabelStatisticsHandler(c *gin.Context) {
+	var answer *model.Answer
+	var version *int
+	if answerIDStr, ok := c.GetQuery("answer_id"); ok {
+		answerID, err := strconv.Atoi(answerIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid answer_id"})
+			return
+		}
+		answer, err = h.answerRepo.GetAnswerByID(answerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve answer"})
+			return
+		}
+		if answer == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Answer not found"})
+			return
+		}
+		version = &answer.QuestionnaireVersion
+	}
+
+	// 集計対象の質問票と、同じ質問票に対する回答のうち絞り込み条件に一致するものを取得
+	questionnaire, population, ok := h.loadPopulation(c, version)
+	if !ok {
+		return
+	}
+
+	// ラベルごとの回答数を集計
+	frequencies := service.CalculateLabelFrequencies(population, questionnaire)
+
+	response := gin.H{
+		"questionnaire_version": questionnaire.Version,
+		"frequencies":           frequencies,
+	}
+
+	// 指定された回答のラベルの珍しさ
+	if answer != nil {
+		philoLabel := service.CalculatePhiloLabel(answer, questionnaire)
+		response["answer"] = gin.H{
+			"answer_id": answer.ID,
+			"label":     philoLabel,
+			"main":      service.FindLabelRarity(frequencies.Main, philoLabel.MainLabel),
+			"sub":       service.FindLabelRarity(frequencies.Sub, philoLabel.SubLabel),
+			"full":      service.FindLabelRarity(frequencies.Full, philoLabel.FullLabel),
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTrendsHandler 週ごとまたは月ごとの軸スコアの平均・メインラベルの割合・回答数の推移を取得（認証不要）
+// クエリパラメータintervalで期間の単位（week / month、既定はweek）、
+// questionnaire_versionで質問票（省略時は最新）を指定し、from / toなどの絞り込み条件も指定できる
+func (h *Handler) GetTrendsHandler(c *gin.Context) {
+	interval := c.DefaultQuery("interval", service.TrendIntervalWeek)
+
+	// 集計対象の質問票と、同じ質問票に対する回答のうち絞り込み条件に一致するものを取得
+	questionnaire, population, ok := h.loadPopulation(c, nil)
+	if !ok {
+		return
+	}
+
+	trends, err := service.CalculateTrends(population, questionnaire, interval)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interval parameter", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questionnaire_version": questionnaire.Version,
+		"interval":              interval,
+		"trends":                trends,
+	})
+}
+
+// GetQuestionStatisticsHandler 設問ごとの回答値の分布・平均・標準偏差と設問間の相関行列を取得（認証不要）
+// 似た内容の設問や意図どおりに回答されていない設問を見つけるために使う
+// クエリパラメータmethodで相関係数の種類（pearson / spearman、既定はpearson）、
+// questionnaire_versionで質問票（省略時は最新）を指定し、from / toなどの絞り込み条件も指定できる
+func (h *Handler) GetQuestionStatisticsHandler(c *gin.Context) {
+	method := c.DefaultQuery("method", service.CorrelationPearson)
+
+	// 集計対象の質問票と、同じ質問票に対する回答のうち絞り込み条件に一致するものを取得
+	questionnaire, population, ok := h.loadPopulation(c, nil)
+	if !ok {
+		return
+	}
+
+	correlations, err := service.CalculateCorrelationMatrix(population, questionnaire, method)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid method parameter", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questionnaire_version": questionnaire.Version,
+		"total":                 len(population),
+		"questions":             service.CalculateQuestionStatistics(population, questionnaire),
+		"correlations":          correlations,
+	})
+}
+
+// loadPopulation 統計の母集団として、質問票と、同じ質問票に対する回答のうちクエリパラメータの絞り込み条件に一致するものを取得
+// versionがnilの場合はクエリパラメータquestionnaire_version（省略時は最新）の質問票を対象とする
+// 取得できない場合はエラーレスポンスを書き込み、falseを返す
+func (h *Handler) loadPopulation(c *gin.Context, version *int) (*model.Questionnaire, []*model.Answer, bool) {
+	if version == nil {
+		if versionStr, ok := c.GetQuery("questionnaire_version"); ok {
+			v, err := strconv.Atoi(versionStr)
+			if err != nil || v <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid questionnaire_version"})
+				return nil, nil, false
+			}
+			version = &v
+		}
+	}
+
+	questionnaire, err := h.findQuestionnaire(version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve questionnaire"})
+		return nil, nil, false
+	}
+	if questionnaire == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Questionnaire not found"})
+		return nil, nil, false
+	}
+
+	filter, err := parseAnswerFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameter", "details": err.Error()})
+		return nil, nil, false
+	}
+	filter.QuestionnaireVersion = &questionnaire.Version
+	answers, err := h.answerRepo.FindAnswers(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve all answers"})
+		return nil, nil, false
+	}
+
+	population := make([]*model.Answer, 0, len(answers))
+	for i := range answers {
+		population = append(population, &answers[i])
+	}
+	return questionnaire, population, true
+}
+
+// parseAnswerFilter クエリパラメータから統計の母集団の絞り込み条件を取得
+// min_duration_ms / max_duration_ms: 回答時間（ミリ秒）の範囲（極端に速い回答の除外など）
+// locale / client_version: 回答時のロケールとクライアントのバージョン
+// include_flagged: trueの場合は品質判定で印の付いた回答も母集団に含める（既定では除外）
+// dedupe: trueの場合は回答者ごとの最新の回答のみを母集団とする（1人1票、既定ではすべての回答）
+// from / to: 回答日時の範囲（"2006-01-02"またはRFC3339、fromは含みtoは含まない。日付のみのtoはその日の終わりまで）
+func parseAnswerFilter(c *gin.Context) (model.AnswerFilter, error) {
+	var filter model.AnswerFilter
+
+	for _, param := range []struct {
+		name string
+		dest **int
+	}{
+		{"min_duration_ms", &filter.MinDurationMs},
+		{"max_duration_ms", &filter.MaxDurationMs},
+	} {
+		valueStr, ok := c.GetQuery(param.name)
+		if !ok {
+			continue
+		}
+		value, err := strconv.Atoi(valueStr)
+		if err != nil || value < 0 {
+			return filter, fmt.Errorf("%s must be a non-negative integer", param.name)
+		}
+		*param.dest = &value
+	}
+
+	if locale, ok := c.GetQuery("locale"); ok {
+		filter.Locale = &locale
+	}
+	if clientVersion, ok := c.GetQuery("client_version"); ok {
+		filter.ClientVersion = &clientVersion
+	}
+	if includeFlaggedStr, ok := c.GetQuery("include_flagged"); ok {
+		includeFlagged, err := strconv.ParseBool(includeFlaggedStr)
+		if err != nil {
+			return filter, fmt.Errorf("include_flagged must be a boolean")
+		}
+		filter.IncludeFlagged = includeFlagged
+	}
+	if dedupeStr, ok := c.GetQuery("dedupe"); ok {
+		dedupe, err := strconv.ParseBool(dedupeStr)
+		if err != nil {
+			return filter, fmt.Errorf("dedupe must be a boolean")
+		}
+		filter.LatestPerRespondent = dedupe
+	}
+	if fromStr, ok := c.GetQuery("from"); ok {
+		from, _, err := parseFilterTime(fromStr)
+		if err != nil {
+			return filter, fmt.Errorf("from must be a date (2006-01-02) or an RFC3339 timestamp")
+		}
+		filter.CreatedFrom = &from
+	}
+	if toStr, ok := c.GetQuery("to"); ok {
+		to, dateOnly, err := parseFilterTime(toStr)
+		if err != nil {
+			return filter, fmt.Errorf("to must be a date (2006-01-02) or an RFC3339 timestamp")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.CreatedTo = &to
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, fmt.Errorf("from must be before to")
+	}
+
+	return filter, nil
+}
+
+// parseFilterTime 日付（UTCの0時）またはRFC3339の日時を解析し、日付のみの指定だったかどうかも返す
+func parseFilterTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t.UTC(), false, err
+}
+
+// parseDistanceMetric クエリパラメータmetricから距離指標を作成（省略した場合は重み付きユークリッド距離）
+// euclidean / manhattan / cosine / chebyshev / mahalanobis を指定でき、
+// mahalanobisの共分散はfilterに一致する回答（統計の母集団）から推定する
+func (h *Handler) parseDistanceMetric(c *gin.Context, questionnaire *model.Questionnaire, filter model.AnswerFilter) (service.DistanceMetric, error) {
+	name := c.Query("metric")
+	var population []model.Answer
+	if service.MetricNeedsPopulation(name) {
+		population = h.answerIndex.Find(filter)
+	}
+	return service.NewDistanceMetric(name, questionnaire, population)
+}
+
+// 既定の近傍ユーザー数の分布の半径（距離指標で取りうる最大の距離に対する割合）
+// 距離指標ごとに尺度が異なるため割合で指定する（16問・5段階の質問票のユークリッド距離では最大16なので半径1, 2, 3, 5, 10）
+var defaultNeighborRadiusFractions = []float64{1.0 / 16, 2.0 / 16, 3.0 / 16, 5.0 / 16, 10.0 / 16}
+
+// defaultNeighborRadiusFraction 半径を1つだけ使う場合の既定の半径（最大の距離に対する割合）
+const defaultNeighborRadiusFraction = 3.0 / 16
+
+// 近傍ユーザー数の分布の半径の数と、珍しさの計算に使うk番目に近い回答者のkの上限
+const (
+	maxNeighborRadii = 50
+	maxNearestK      = 1000
+)
+
+// parseNeighborRadii クエリパラメータから近傍ユーザー数を数える半径の一覧を取得（昇順、重複なし）
+// radii: カンマ区切りの半径（例: "0.5,1,1.5"）
+// bins: 距離指標で取りうる最大の距離を等分する数（例: 20なら最大の距離の1/20刻み）
+// どちらも省略した場合は距離指標の最大の距離にdefaultNeighborRadiusFractionsの割合を掛けた半径
+func parseNeighborRadii(c *gin.Context, metric service.DistanceMetric) ([]float64, error) {
+	radiiStr, hasRadii := c.GetQuery("radii")
+	binsStr, hasBins := c.GetQuery("bins")
+
+	switch {
+	case hasRadii && hasBins:
+		return nil, fmt.Errorf("radii and bins cannot be specified together")
+
+	case hasRadii:
+		parts := strings.Split(radiiStr, ",")
+		if len(parts) > maxNeighborRadii {
+			return nil, fmt.Errorf("at most %d radii can be specified", maxNeighborRadii)
+		}
+		radii := make([]float64, 0, len(parts))
+		for _, part := range parts {
+			radius, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil || radius < 0 || math.IsInf(radius, 0) || math.IsNaN(radius) {
+				return nil, fmt.Errorf("radii must be non-negative numbers")
+			}
+			radii = append(radii, radius)
+		}
+		sort.Float64s(radii)
+		unique := radii[:1]
+		for _, radius := range radii[1:] {
+			if radius != unique[len(unique)-1] {
+				unique = append(unique, radius)
+			}
+		}
+		return unique, nil
+
+	case hasBins:
+		bins, err := strconv.Atoi(binsStr)
+		if err != nil || bins < 1 || bins > maxNeighborRadii {
+			return nil, fmt.Errorf("bins must be an integer between 1 and %d", maxNeighborRadii)
+		}
+		radii := make([]float64, bins)
+		for i := range radii {
+			radii[i] = metric.MaxDistance() * float64(i+1) / float64(bins)
+		}
+		return radii, nil
+	}
+
+	radii := make([]float64, len(defaultNeighborRadiusFractions))
+	for i, fraction := range defaultNeighborRadiusFractions {
+		radii[i] = metric.MaxDistance() * fraction
+	}
+	return radii, nil
+}
+
+// parseNearestK クエリパラメータnearest_kから、距離を返す近い回答者の順位を取得（既定は1 = 最も近い回答者）
+func parseNearestK(c *gin.Context) (int, error) {
+	kStr, ok := c.GetQuery("nearest_k")
+	if !ok {
+		return 1, nil
+	}
+	k, err := strconv.Atoi(kStr)
+	if err != nil || k < 1 || k > maxNearestK {
+		return 0, fmt.Errorf("nearest_k must be an integer between 1 and %d", maxNearestK)
+	}
+	return k, nil
 }
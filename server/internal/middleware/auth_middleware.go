@@ -42,3 +42,37 @@ func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware 任意のJWT認証ミドルウェア
+// Authorizationヘッダーがない場合は匿名として処理を続行し、
+// ヘッダーがある場合はAuthMiddlewareと同様に検証してユーザー情報をコンテキストに保存する
+func OptionalAuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+
+		// "Bearer "プレフィックスを除去
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		if tokenString == authHeader {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
+			c.Abort()
+			return
+		}
+
+		// トークン検証（不正なトークンは匿名扱いにせず拒否する）
+		claims, err := authService.ValidateToken(tokenString)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+
+		c.Next()
+	}
+}
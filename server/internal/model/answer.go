@@ -1,41 +1,197 @@
 package model
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
-// Answer ユーザーの16次元回答ベクトルを表す構造体
+// Answer ユーザーの回答ベクトルを表す構造体
 // UserIDがnilの場合は匿名の統計データとして扱う
 type Answer struct {
-	ID        int       `json:"id"`
-	UserID    *int      `json:"user_id,omitempty"`
-	Answer01  int16     `json:"answer_01"`
-	Answer02  int16     `json:"answer_02"`
-	Answer03  int16     `json:"answer_03"`
-	Answer04  int16     `json:"answer_04"`
-	Answer05  int16     `json:"answer_05"`
-	Answer06  int16     `json:"answer_06"`
-	Answer07  int16     `json:"answer_07"`
-	Answer08  int16     `json:"answer_08"`
-	Answer09  int16     `json:"answer_09"`
-	Answer10  int16     `json:"answer_10"`
-	Answer11  int16     `json:"answer_11"`
-	Answer12  int16     `json:"answer_12"`
-	Answer13  int16     `json:"answer_13"`
-	Answer14  int16     `json:"answer_14"`
-	Answer15  int16     `json:"answer_15"`
-	Answer16  int16     `json:"answer_16"`
+	ID                   int          `json:"id"`
+	UserID               *int         `json:"user_id,omitempty"`
+	DeviceID             *string      `json:"-"`                     // 匿名回答者の端末ID（回答者ごとの重複排除に使い、レスポンスには含めない）
+	QuestionnaireVersion int          `json:"questionnaire_version"` // 回答時の質問票バージョン
+	Values               AnswerVector `json:"answers"`               // 質問票の設問順に並んだ回答値（nullは未回答）
+
+	// 回答時間などの付帯情報と品質判定の結果
+	// 回答IDは連番で推測できるため、認証不要のAPIで回答者ごとの情報を返さないようレスポンスには含めない
+	AnswerMetadata `json:"-"`
+	Quality        AnswerQuality `json:"-"`
+
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
-// AnswerVector 16次元の回答ベクトルのみを表す構造体（配列形式でも扱いやすいように）
-type AnswerVector [16]int16
+// 回答の品質判定の理由
+const (
+	QualityReasonLongString   = "long_string"   // 同じ値の回答が長く連続している
+	QualityReasonZeroVariance = "zero_variance" // すべての回答が同じ値
+	QualityReasonTooFast      = "too_fast"      // 設問を読めないほど短い時間で回答している
+)
+
+// AnswerQuality 回答の品質判定の結果
+// Flaggedの回答は不注意な回答の可能性が高いため、既定では統計の母集団から除外する
+type AnswerQuality struct {
+	Flagged bool           `json:"flagged"`
+	Reasons QualityReasons `json:"reasons"`
+}
+
+// AnswerMetadata 回答送信時の付帯情報（すべて任意）
+// 極端に速い回答の除外や、回答に迷った設問の分析に使う
+type AnswerMetadata struct {
+	ResponseTimesMs ResponseTimes `json:"response_times_ms,omitempty"` // 設問ごとの回答時間（ミリ秒、設問順）
+	DurationMs      *int          `json:"duration_ms,omitempty"`       // 回答開始から送信までの時間（ミリ秒）
+	Locale          *string       `json:"locale,omitempty"`            // 例: "ja-JP"
+	ClientVersion   *string       `json:"client_version,omitempty"`
+}
+
+// AnswerFilter 回答の絞り込み条件（nilの条件は適用しない）
+// 付帯情報の条件を指定した場合、その情報を持たない回答は除外される
+type AnswerFilter struct {
+	QuestionnaireVersion *int
+	MinDurationMs        *int
+	MaxDurationMs        *int
+	Locale               *string
+	ClientVersion        *string
+	IncludeFlagged       bool       // trueの場合は品質判定で印の付いた回答も含める
+	LatestPerRespondent  bool       // trueの場合は回答者ごとに条件に一致する最新の回答のみ（1人1票）
+	CreatedFrom          *time.Time // この日時以降に作成された回答（UTC）
+	CreatedTo            *time.Time // この日時より前に作成された回答（UTC、この日時は含まない）
+}
+
+// RespondentKey 同じ回答者の回答をまとめるためのキー
+// ユーザーに紐づいた回答はユーザーID、匿名の回答は端末IDで識別し、どちらもない回答は回答ごとに別の回答者とみなす
+func (a *Answer) RespondentKey() string {
+	switch {
+	case a.UserID != nil:
+		return fmt.Sprintf("user:%d", *a.UserID)
+	case a.DeviceID != nil:
+		return "device:" + *a.DeviceID
+	}
+	return fmt.Sprintf("answer:%d", a.ID)
+}
+
+// AnswerVector 回答ベクトル
+// 次元数は質問票の設問数で決まり、DBにはJSON配列のテキストとして保存する
+// nilの要素は「スキップ / わからない」を表し、中立の回答（スケールの中央）とは区別する
+type AnswerVector []*int16
 
-// ToVector Answer構造体から16次元ベクトルを抽出
+// ToVector Answer構造体から回答ベクトルを抽出
 func (a *Answer) ToVector() AnswerVector {
-	return AnswerVector{
-		a.Answer01, a.Answer02, a.Answer03, a.Answer04,
-		a.Answer05, a.Answer06, a.Answer07, a.Answer08,
-		a.Answer09, a.Answer10, a.Answer11, a.Answer12,
-		a.Answer13, a.Answer14, a.Answer15, a.Answer16,
+	return a.Values
+}
+
+// Value database/sql/driver.Valuerの実装（JSON配列として保存）
+func (v AnswerVector) Value() (driver.Value, error) {
+	if v == nil {
+		v = AnswerVector{}
+	}
+	b, err := json.Marshal([]*int16(v))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan database/sql.Scannerの実装（JSON配列のテキストから復元）
+func (v *AnswerVector) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	case nil:
+		*v = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for AnswerVector: %T", src)
+	}
+	return json.Unmarshal(data, (*[]*int16)(v))
+}
+
+// IsAnswered i番目（0始まり）の設問に回答しているかどうか
+func (v AnswerVector) IsAnswered(i int) bool {
+	return i < len(v) && v[i] != nil
+}
+
+// SkippedCount スキップされた設問の数
+func (v AnswerVector) SkippedCount() int {
+	count := 0
+	for _, value := range v {
+		if value == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// ResponseTimes 設問ごとの回答時間（ミリ秒）
+// DBにはJSON配列のテキストとして保存し、記録がない場合はNULLとする
+// nilの要素は時間を計測できなかった設問を表す
+type ResponseTimes []*int
+
+// Value driver.Valuerの実装（JSON配列のテキストとして保存）
+func (t ResponseTimes) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]*int(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装（JSON配列のテキストから読み込む）
+func (t *ResponseTimes) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*t = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for ResponseTimes: %T", src)
+	}
+	return json.Unmarshal(data, (*[]*int)(t))
+}
+
+// QualityReasons 品質判定の理由の一覧
+// DBにはJSON配列のテキストとして保存する（判定前の回答はNULL）
+type QualityReasons []string
+
+// Value driver.Valuerの実装（JSON配列のテキストとして保存）
+func (r QualityReasons) Value() (driver.Value, error) {
+	if r == nil {
+		r = QualityReasons{}
+	}
+	b, err := json.Marshal([]string(r))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装（JSON配列のテキストから読み込む）
+func (r *QualityReasons) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*r = QualityReasons{}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for QualityReasons: %T", src)
 	}
+	return json.Unmarshal(data, (*[]string)(r))
 }
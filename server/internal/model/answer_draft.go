@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// AnswerDraftTTL 下書きの有効期限（最後に更新してからの期間）
+const AnswerDraftTTL = 7 * 24 * time.Hour
+
+// AnswerDraft 確定前の回答（途中保存用）
+// Valuesは質問票の設問数分の長さを持ち、まだ回答していない設問はnil
+// 確定時にnilのまま残った設問はスキップとして扱う
+type AnswerDraft struct {
+	ID                   string       `json:"id"` // 下書きを再開するためのトークン
+	UserID               *int         `json:"user_id,omitempty"`
+	QuestionnaireVersion int          `json:"questionnaire_version"`
+	Values               AnswerVector `json:"answers"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            *time.Time   `json:"updated_at,omitempty"`
+	ExpiresAt            time.Time    `json:"expires_at"`
+}
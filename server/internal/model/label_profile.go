@@ -0,0 +1,14 @@
+package model
+
+// LabelProfile ラベルのタイプ解説
+// Codeはメインラベル（例: "SVOP"）またはサブラベル（例: "ADSL"）で、Groupで区別する
+type LabelProfile struct {
+	QuestionnaireVersion int     `json:"questionnaire_version"`
+	Group                string  `json:"group"` // AxisGroupMain or AxisGroupSub
+	Code                 string  `json:"code"`
+	Name                 string  `json:"name"`                  // 例: "構造 × 徳 × 存在 × ポストモダン"
+	Description          *string `json:"description,omitempty"` // 傾向
+	Strengths            *string `json:"strengths,omitempty"`
+	Weaknesses           *string `json:"weaknesses,omitempty"`
+	Relations            *string `json:"relations,omitempty"` // 他のタイプとの距離感
+}
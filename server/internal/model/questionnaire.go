@@ -0,0 +1,113 @@
+package model
+
+import "time"
+
+// ラベルのグループ
+const (
+	AxisGroupMain = "main" // メインラベル（例: "SVOP"）を構成する軸
+	AxisGroupSub  = "sub"  // サブラベル（例: "LDSA"）を構成する軸
+)
+
+// Questionnaire バージョン管理された質問票
+// 質問文を変更する場合は新しいバージョンを作成し、過去の回答の意味が変わらないようにする
+type Questionnaire struct {
+	Version        int          `json:"version"`
+	Title          string       `json:"title"`
+	Description    *string      `json:"description,omitempty"`
+	ScaleMin       int16        `json:"scale_min"`       // 回答値の最小（例: -2）
+	ScaleMax       int16        `json:"scale_max"`       // 回答値の最大（例: 2）
+	BalancedLetter bool         `json:"balanced_letter"` // バランス型の軸をラベルの文字"X"で表すか（falseなら極の文字で表す）
+	Questions      []Question   `json:"questions"`
+	ScaleLabels    []ScaleLabel `json:"scale_labels"`
+	Axes           []Axis       `json:"axes"` // ラベル内の並び順
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// Question 質問票の1問
+type Question struct {
+	Position     int     `json:"position"` // 回答ベクトル上の位置（1始まり）
+	Category     string  `json:"category"`
+	Text         string  `json:"text"`
+	AxisCode     *string `json:"axis_code,omitempty"` // スコアを加算する軸（なければラベル判定に使わない）
+	Weight       float64 `json:"weight"`              // 軸スコアと距離計算での重み
+	ReverseKeyed bool    `json:"reverse_keyed"`       // 逆転項目（符号を反転して軸スコアに加算）
+}
+
+// ScaleLabel 回答値と選択肢ラベルの対応
+type ScaleLabel struct {
+	Value int16  `json:"value"`
+	Label string `json:"label"`
+}
+
+// Axis ラベルの1文字を決める軸の定義
+// 軸に属する設問の重み付き合計スコア（スケールの中央を0とする）がThreshold以上なら正の極、未満なら負の極の文字になる
+// ただしThreshold付近でどちらの極にも寄っていないスコアはバランス型とする（質問票のBalancedLetterがtrueなら文字は"X"）
+type Axis struct {
+	Code                string  `json:"code"`
+	Name                string  `json:"name"`
+	Group               string  `json:"group"` // AxisGroupMain or AxisGroupSub
+	PositiveLetter      string  `json:"positive_letter"`
+	PositiveName        string  `json:"positive_name"`
+	PositiveDescription *string `json:"positive_description,omitempty"`
+	NegativeLetter      string  `json:"negative_letter"`
+	NegativeName        string  `json:"negative_name"`
+	NegativeDescription *string `json:"negative_description,omitempty"`
+	Threshold           float64 `json:"threshold"`
+}
+
+// AxisQuestionIndexes 指定した軸に属する設問の回答ベクトル上のインデックス（0始まり）を設問順に返す
+func (q *Questionnaire) AxisQuestionIndexes(axisCode string) []int {
+	indexes := []int{}
+	for i, question := range q.Questions {
+		if question.AxisCode != nil && *question.AxisCode == axisCode {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// GroupAxes 指定したグループの軸をラベル内の並び順で返す
+func (q *Questionnaire) GroupAxes(group string) []Axis {
+	axes := []Axis{}
+	for _, axis := range q.Axes {
+		if axis.Group == group {
+			axes = append(axes, axis)
+		}
+	}
+	return axes
+}
+
+// Weights 設問ごとの重みを回答ベクトルの並び順で返す
+func (q *Questionnaire) Weights() []float64 {
+	weights := make([]float64, len(q.Questions))
+	for i, question := range q.Questions {
+		weights[i] = question.Weight
+	}
+	return weights
+}
+
+// ScaleMidpoint スケールの中央の値（"どちらとも言えない"に相当）
+func (q *Questionnaire) ScaleMidpoint() float64 {
+	return (float64(q.ScaleMin) + float64(q.ScaleMax)) / 2
+}
+
+// ScaleHalfRange スケールの中央から端までの幅
+func (q *Questionnaire) ScaleHalfRange() float64 {
+	return (float64(q.ScaleMax) - float64(q.ScaleMin)) / 2
+}
+
+// InScale 回答値がスケールの範囲内かどうか
+func (q *Questionnaire) InScale(value int16) bool {
+	return value >= q.ScaleMin && value <= q.ScaleMax
+}
+
+// ScoredValue 採点キーを適用したindex番目（0始まり）の設問の得点
+// 回答値をスケールの中央が0になるよう変換し、逆転項目は符号を反転して重みを掛ける
+func (q *Questionnaire) ScoredValue(index int, value int16) float64 {
+	question := q.Questions[index]
+	score := (float64(value) - q.ScaleMidpoint()) * question.Weight
+	if question.ReverseKeyed {
+		return -score
+	}
+	return score
+}
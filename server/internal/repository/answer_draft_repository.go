@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// AnswerDraftRepository 回答の下書きのリポジトリインターフェース
+// 期限切れの下書きは存在しないものとして扱う
+type AnswerDraftRepository interface {
+	// CreateDraft 新規下書きを作成（IDと有効期限はここで設定する）
+	CreateDraft(draft *model.AnswerDraft) error
+	// GetDraftByID IDで有効期限内の下書きを取得
+	GetDraftByID(id string) (*model.AnswerDraft, error)
+	// SetDraftAnswer 下書きの1問分の回答を保存し、有効期限を延長
+	SetDraftAnswer(id string, index int, value *int16) (*model.AnswerDraft, error)
+	// FinalizeDraft 下書きを削除し、同じトランザクションで回答として保存
+	FinalizeDraft(draft *model.AnswerDraft, answer *model.Answer) error
+	// DeleteExpiredDrafts 期限切れの下書きをすべて削除
+	DeleteExpiredDrafts() (int64, error)
+}
+
+// ErrDraftConflict 同じ下書きへの同時の保存が続き、再試行しても保存できなかった
+var ErrDraftConflict = errors.New("answer draft was modified concurrently")
+
+// maxDraftUpdateAttempts 同じ下書きへの同時の保存と競合した場合に保存を試みる回数
+const maxDraftUpdateAttempts = 5
+
+type answerDraftRepository struct {
+	db *sql.DB
+}
+
+// NewAnswerDraftRepository AnswerDraftRepositoryの新規インスタンスを作成
+func NewAnswerDraftRepository(db *sql.DB) AnswerDraftRepository {
+	return &answerDraftRepository{db: db}
+}
+
+// draftNow 下書きの時刻計算に使う現在時刻
+// DBの種類によらず同じ形式で比較できるよう、UTCの秒単位にそろえる
+func draftNow() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// newDraftID 推測できないランダムな下書きIDを生成
+func newDraftID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// CreateDraft 下書きをDBに保存
+func (r *answerDraftRepository) CreateDraft(draft *model.AnswerDraft) error {
+	id, err := newDraftID()
+	if err != nil {
+		return err
+	}
+
+	now := draftNow()
+	draft.ID = id
+	draft.CreatedAt = now
+	draft.ExpiresAt = now.Add(model.AnswerDraftTTL)
+
+	query := `
+		INSERT INTO answer_drafts (id, user_id, questionnaire_version, answer_vector, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err = r.db.Exec(
+		query,
+		draft.ID,
+		draft.UserID,
+		draft.QuestionnaireVersion,
+		draft.Values,
+		draft.CreatedAt,
+		draft.ExpiresAt,
+	)
+	return err
+}
+
+// GetDraftByID IDで有効期限内の下書きを取得
+func (r *answerDraftRepository) GetDraftByID(id string) (*model.AnswerDraft, error) {
+	query := `
+		SELECT id, user_id, questionnaire_version, answer_vector, created_at, updated_at, expires_at
+		FROM answer_drafts
+		WHERE id = $1 AND expires_at > $2`
+
+	draft := &model.AnswerDraft{}
+	err := r.db.QueryRow(query, id, draftNow()).Scan(
+		&draft.ID, &draft.UserID, &draft.QuestionnaireVersion, &draft.Values,
+		&draft.CreatedAt, &draft.UpdatedAt, &draft.ExpiresAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// SetDraftAnswer 下書きのindex番目（0始まり）の設問の回答を保存し、有効期限を延長
+// 読み込んだ時点から回答ベクトルが変わっていない場合のみ更新し、他の設問への同時の保存を上書きしない
+// （変わっていた場合は読み込み直して再試行する）
+// 下書きが存在しないか期限切れの場合はsql.ErrNoRowsを返す
+func (r *answerDraftRepository) SetDraftAnswer(id string, index int, value *int16) (*model.AnswerDraft, error) {
+	query := `
+		UPDATE answer_drafts
+		SET answer_vector = $1, updated_at = $2, expires_at = $3
+		WHERE id = $4 AND answer_vector = $5 AND expires_at > $2`
+
+	for attempt := 0; attempt < maxDraftUpdateAttempts; attempt++ {
+		draft, err := r.GetDraftByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if draft == nil {
+			return nil, sql.ErrNoRows
+		}
+		if index < 0 || index >= len(draft.Values) {
+			return nil, fmt.Errorf("answer index %d out of range", index)
+		}
+
+		// 読み込んだ時点の回答ベクトル（すべての保存はAnswerVector.Valueを通すため、同じ内容なら同じ文字列になる）
+		current, err := draft.Values.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		draft.Values[index] = value
+		now := draftNow()
+		expiresAt := now.Add(model.AnswerDraftTTL)
+
+		result, err := r.db.Exec(query, draft.Values, now, expiresAt, id, current)
+		if err != nil {
+			return nil, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		// 0件の場合は読み込んだ後に他の保存で変更されたか、期限切れになった
+		if rowsAffected > 0 {
+			draft.UpdatedAt = &now
+			draft.ExpiresAt = expiresAt
+			return draft, nil
+		}
+	}
+
+	return nil, ErrDraftConflict
+}
+
+// FinalizeDraft 下書きを削除し、同じトランザクションで回答として保存
+// draftは確定前に読み込んだ下書きで、読み込んだ後に回答が変更された場合や、
+// すでに確定済み・期限切れの場合は何も保存せずsql.ErrNoRowsを返す（同じ下書きから回答が重複して作られない）
+func (r *answerDraftRepository) FinalizeDraft(draft *model.AnswerDraft, answer *model.Answer) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 下書きを削除して確定する権利を得る（同時に確定した場合、削除できるのは一方のみ）
+	result, err := tx.Exec(
+		`DELETE FROM answer_drafts WHERE id = $1 AND answer_vector = $2 AND expires_at > $3`,
+		draft.ID, draft.Values, draftNow(),
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertAnswer(tx, answer); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteExpiredDrafts 期限切れの下書きをすべて削除し、削除した件数を返す
+func (r *answerDraftRepository) DeleteExpiredDrafts() (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM answer_drafts WHERE expires_at <= $1`, draftNow())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
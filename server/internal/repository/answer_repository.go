@@ -2,6 +2,8 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/HH19xx/philoCompass/internal/model"
 )
@@ -14,12 +16,23 @@ type AnswerRepository interface {
 	GetLatestAnswerByUserID(userID int) (*model.Answer, error)
 	// GetAllAnswers すべての回答を取得（距離計算用）
 	GetAllAnswers() ([]model.Answer, error)
+	// FindAnswers 条件に一致する回答をすべて取得（統計の母集団用）
+	FindAnswers(filter model.AnswerFilter) ([]model.Answer, error)
 	// LinkAnswerToUser 匿名回答をユーザーに紐づける
 	LinkAnswerToUser(answerID int, userID int) error
 	// GetAnswerByID IDで回答を取得
 	GetAnswerByID(answerID int) (*model.Answer, error)
 }
 
+// answerColumns 回答の取得時に読み込むカラム（scanAnswerの順序と対応）
+const answerColumns = `id, user_id, device_id, questionnaire_version, answer_vector,
+		response_times_ms, duration_ms, locale, client_version,
+		quality_flagged, quality_reasons, created_at`
+
+// timestampFormat 作成日時の比較に使う日時の形式
+// SQLiteのDATETIME('now')と同じ形式の文字列にすることで、PostgreSQLとSQLiteのどちらでも同じ条件で比較できる
+const timestampFormat = "2006-01-02 15:04:05"
+
 type answerRepository struct {
 	db *sql.DB
 }
@@ -31,24 +44,36 @@ func NewAnswerRepository(db *sql.DB) AnswerRepository {
 
 // CreateAnswer 回答データをDBに保存
 func (r *answerRepository) CreateAnswer(answer *model.Answer) error {
+	return insertAnswer(r.db, answer)
+}
+
+// rowQuerier *sql.DBと*sql.Txに共通する1行取得のメソッド
+type rowQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// insertAnswer 回答を保存し、採番されたIDと作成日時をanswerに設定
+// 下書きの確定ではトランザクション内で呼ぶ
+func insertAnswer(q rowQuerier, answer *model.Answer) error {
 	query := `
-		INSERT INTO answers (
-			user_id, answer_01, answer_02, answer_03, answer_04,
-			answer_05, answer_06, answer_07, answer_08, answer_09,
-			answer_10, answer_11, answer_12, answer_13, answer_14,
-			answer_15, answer_16
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-			$11, $12, $13, $14, $15, $16, $17
-		) RETURNING id, created_at`
-
-	err := r.db.QueryRow(
+		INSERT INTO answers (user_id, device_id, questionnaire_version, answer_vector,
+			response_times_ms, duration_ms, locale, client_version,
+			quality_flagged, quality_reasons)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at`
+
+	err := q.QueryRow(
 		query,
 		answer.UserID,
-		answer.Answer01, answer.Answer02, answer.Answer03, answer.Answer04,
-		answer.Answer05, answer.Answer06, answer.Answer07, answer.Answer08,
-		answer.Answer09, answer.Answer10, answer.Answer11, answer.Answer12,
-		answer.Answer13, answer.Answer14, answer.Answer15, answer.Answer16,
+		answer.DeviceID,
+		answer.QuestionnaireVersion,
+		answer.Values,
+		answer.ResponseTimesMs,
+		answer.DurationMs,
+		answer.Locale,
+		answer.ClientVersion,
+		answer.Quality.Flagged,
+		answer.Quality.Reasons,
 	).Scan(&answer.ID, &answer.CreatedAt)
 
 	return err
@@ -57,24 +82,13 @@ func (r *answerRepository) CreateAnswer(answer *model.Answer) error {
 // GetLatestAnswerByUserID 指定ユーザーの最新回答を取得
 func (r *answerRepository) GetLatestAnswerByUserID(userID int) (*model.Answer, error) {
 	query := `
-		SELECT id, user_id, answer_01, answer_02, answer_03, answer_04,
-			answer_05, answer_06, answer_07, answer_08, answer_09,
-			answer_10, answer_11, answer_12, answer_13, answer_14,
-			answer_15, answer_16, created_at
+		SELECT ` + answerColumns + `
 		FROM answers
 		WHERE user_id = $1
 		ORDER BY created_at DESC
 		LIMIT 1`
 
-	answer := &model.Answer{}
-	err := r.db.QueryRow(query, userID).Scan(
-		&answer.ID, &answer.UserID,
-		&answer.Answer01, &answer.Answer02, &answer.Answer03, &answer.Answer04,
-		&answer.Answer05, &answer.Answer06, &answer.Answer07, &answer.Answer08,
-		&answer.Answer09, &answer.Answer10, &answer.Answer11, &answer.Answer12,
-		&answer.Answer13, &answer.Answer14, &answer.Answer15, &answer.Answer16,
-		&answer.CreatedAt,
-	)
+	answer, err := scanAnswer(r.db.QueryRow(query, userID))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -89,10 +103,7 @@ func (r *answerRepository) GetLatestAnswerByUserID(userID int) (*model.Answer, e
 // GetAllAnswers すべての回答データを取得（距離計算用）
 func (r *answerRepository) GetAllAnswers() ([]model.Answer, error) {
 	query := `
-		SELECT id, user_id, answer_01, answer_02, answer_03, answer_04,
-			answer_05, answer_06, answer_07, answer_08, answer_09,
-			answer_10, answer_11, answer_12, answer_13, answer_14,
-			answer_15, answer_16, created_at
+		SELECT ` + answerColumns + `
 		FROM answers
 		ORDER BY created_at DESC`
 
@@ -102,24 +113,79 @@ func (r *answerRepository) GetAllAnswers() ([]model.Answer, error) {
 	}
 	defer rows.Close()
 
-	answers := []model.Answer{}
-	for rows.Next() {
-		var answer model.Answer
-		err := rows.Scan(
-			&answer.ID, &answer.UserID,
-			&answer.Answer01, &answer.Answer02, &answer.Answer03, &answer.Answer04,
-			&answer.Answer05, &answer.Answer06, &answer.Answer07, &answer.Answer08,
-			&answer.Answer09, &answer.Answer10, &answer.Answer11, &answer.Answer12,
-			&answer.Answer13, &answer.Answer14, &answer.Answer15, &answer.Answer16,
-			&answer.CreatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		answers = append(answers, answer)
+	return scanAnswers(rows)
+}
+
+// FindAnswers 条件に一致する回答をすべて取得
+// 品質判定で印の付いた回答は、IncludeFlaggedを指定しない限り除外する
+// LatestPerRespondentを指定した場合は、条件に一致する回答のうち回答者ごとに最新の回答のみを返す
+// 設問数が異なる回答同士は比較できないため、統計では質問票のバージョンを必ず指定する
+func (r *answerRepository) FindAnswers(filter model.AnswerFilter) ([]model.Answer, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.QuestionnaireVersion != nil {
+		addCondition("questionnaire_version = $%d", *filter.QuestionnaireVersion)
+	}
+	if filter.MinDurationMs != nil {
+		addCondition("duration_ms >= $%d", *filter.MinDurationMs)
+	}
+	if filter.MaxDurationMs != nil {
+		addCondition("duration_ms <= $%d", *filter.MaxDurationMs)
+	}
+	if filter.Locale != nil {
+		addCondition("locale = $%d", *filter.Locale)
+	}
+	if filter.ClientVersion != nil {
+		addCondition("client_version = $%d", *filter.ClientVersion)
+	}
+	if !filter.IncludeFlagged {
+		addCondition("quality_flagged = $%d", false)
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= $%d", filter.CreatedFrom.UTC().Format(timestampFormat))
+	}
+	if filter.CreatedTo != nil {
+		addCondition("created_at < $%d", filter.CreatedTo.UTC().Format(timestampFormat))
+	}
+
+	query := `
+		SELECT ` + answerColumns + `
+		FROM answers`
+	if len(conditions) > 0 {
+		query += `
+		WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	// 回答者ごとに条件に一致する最新の回答のみ（回答者の識別はmodel.Answer.RespondentKeyと同じ）
+	if filter.LatestPerRespondent {
+		query = `
+		SELECT ` + answerColumns + `
+		FROM (
+			SELECT ` + answerColumns + `,
+				ROW_NUMBER() OVER (
+					PARTITION BY COALESCE('user:' || user_id, 'device:' || device_id, 'answer:' || id)
+					ORDER BY created_at DESC, id DESC
+				) AS respondent_rank
+			FROM (` + query + `) filtered
+		) ranked
+		WHERE respondent_rank = 1`
+	}
+
+	query += `
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return answers, nil
+	return scanAnswers(rows)
 }
 
 // LinkAnswerToUser 匿名回答をユーザーに紐づける
@@ -150,22 +216,11 @@ func (r *answerRepository) LinkAnswerToUser(answerID int, userID int) error {
 // GetAnswerByID IDで回答を取得
 func (r *answerRepository) GetAnswerByID(answerID int) (*model.Answer, error) {
 	query := `
-		SELECT id, user_id, answer_01, answer_02, answer_03, answer_04,
-			answer_05, answer_06, answer_07, answer_08, answer_09,
-			answer_10, answer_11, answer_12, answer_13, answer_14,
-			answer_15, answer_16, created_at
+		SELECT ` + answerColumns + `
 		FROM answers
 		WHERE id = $1`
 
-	answer := &model.Answer{}
-	err := r.db.QueryRow(query, answerID).Scan(
-		&answer.ID, &answer.UserID,
-		&answer.Answer01, &answer.Answer02, &answer.Answer03, &answer.Answer04,
-		&answer.Answer05, &answer.Answer06, &answer.Answer07, &answer.Answer08,
-		&answer.Answer09, &answer.Answer10, &answer.Answer11, &answer.Answer12,
-		&answer.Answer13, &answer.Answer14, &answer.Answer15, &answer.Answer16,
-		&answer.CreatedAt,
-	)
+	answer, err := scanAnswer(r.db.QueryRow(query, answerID))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -176,3 +231,36 @@ func (r *answerRepository) GetAnswerByID(answerID int) (*model.Answer, error) {
 
 	return answer, nil
 }
+
+// rowScanner *sql.Rowと*sql.Rowsに共通するScanメソッド
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAnswer 1行の回答データを読み込む（カラムはanswerColumnsの順）
+func scanAnswer(row rowScanner) (*model.Answer, error) {
+	answer := &model.Answer{}
+	err := row.Scan(
+		&answer.ID, &answer.UserID, &answer.DeviceID, &answer.QuestionnaireVersion, &answer.Values,
+		&answer.ResponseTimesMs, &answer.DurationMs, &answer.Locale, &answer.ClientVersion,
+		&answer.Quality.Flagged, &answer.Quality.Reasons, &answer.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// scanAnswers 複数行の回答データを読み込む
+func scanAnswers(rows *sql.Rows) ([]model.Answer, error) {
+	answers := []model.Answer{}
+	for rows.Next() {
+		answer, err := scanAnswer(rows)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, *answer)
+	}
+
+	return answers, rows.Err()
+}
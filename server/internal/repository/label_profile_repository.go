@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// LabelProfileRepository ラベル解説のリポジトリインターフェース
+type LabelProfileRepository interface {
+	// GetLabelProfilesByQuestionnaireVersion 指定バージョンの質問票のラベル解説をすべて取得
+	GetLabelProfilesByQuestionnaireVersion(version int) ([]model.LabelProfile, error)
+}
+
+type labelProfileRepository struct {
+	db *sql.DB
+}
+
+// NewLabelProfileRepository LabelProfileRepositoryの新規インスタンスを作成
+func NewLabelProfileRepository(db *sql.DB) LabelProfileRepository {
+	return &labelProfileRepository{db: db}
+}
+
+// GetLabelProfilesByQuestionnaireVersion 指定バージョンの質問票のラベル解説をすべて取得
+func (r *labelProfileRepository) GetLabelProfilesByQuestionnaireVersion(version int) ([]model.LabelProfile, error) {
+	query := `
+		SELECT questionnaire_version, label_group, code, name,
+			description, strengths, weaknesses, relations
+		FROM label_profiles
+		WHERE questionnaire_version = $1
+		ORDER BY label_group ASC, code ASC`
+
+	rows, err := r.db.Query(query, version)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []model.LabelProfile{}
+	for rows.Next() {
+		var profile model.LabelProfile
+		err := rows.Scan(
+			&profile.QuestionnaireVersion, &profile.Group, &profile.Code, &profile.Name,
+			&profile.Description, &profile.Strengths, &profile.Weaknesses, &profile.Relations,
+		)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
@@ -10,6 +10,8 @@ import (
 type PhilosopherRepository interface {
 	// GetAllPhilosophers すべての哲学者データを取得（論理削除されていないもののみ）
 	GetAllPhilosophers() ([]model.Philosopher, error)
+	// GetPhilosophersByQuestionnaireVersion 指定バージョンの質問票で回答された哲学者データを取得
+	GetPhilosophersByQuestionnaireVersion(version int) ([]model.Philosopher, error)
 	// GetPhilosopherByID IDで哲学者を取得
 	GetPhilosopherByID(id int) (*model.Philosopher, error)
 }
@@ -26,11 +28,7 @@ func NewPhilosopherRepository(db *sql.DB) PhilosopherRepository {
 // GetAllPhilosophers すべての哲学者データを取得
 func (r *philosopherRepository) GetAllPhilosophers() ([]model.Philosopher, error) {
 	query := `
-		SELECT id, name, era, description,
-			answer_01, answer_02, answer_03, answer_04,
-			answer_05, answer_06, answer_07, answer_08,
-			answer_09, answer_10, answer_11, answer_12,
-			answer_13, answer_14, answer_15, answer_16,
+		SELECT id, name, era, description, questionnaire_version, answer_vector,
 			deleted, created_at, created_by, updated_at, updated_by
 		FROM philosophers
 		WHERE deleted = false
@@ -42,45 +40,38 @@ func (r *philosopherRepository) GetAllPhilosophers() ([]model.Philosopher, error
 	}
 	defer rows.Close()
 
-	philosophers := []model.Philosopher{}
-	for rows.Next() {
-		var p model.Philosopher
-		err := rows.Scan(
-			&p.ID, &p.Name, &p.Era, &p.Description,
-			&p.Answer01, &p.Answer02, &p.Answer03, &p.Answer04,
-			&p.Answer05, &p.Answer06, &p.Answer07, &p.Answer08,
-			&p.Answer09, &p.Answer10, &p.Answer11, &p.Answer12,
-			&p.Answer13, &p.Answer14, &p.Answer15, &p.Answer16,
-			&p.Deleted, &p.CreatedAt, &p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy,
-		)
-		if err != nil {
-			return nil, err
-		}
-		philosophers = append(philosophers, p)
+	return scanPhilosophers(rows)
+}
+
+// GetPhilosophersByQuestionnaireVersion 指定バージョンの質問票で回答された哲学者データを取得
+func (r *philosopherRepository) GetPhilosophersByQuestionnaireVersion(version int) ([]model.Philosopher, error) {
+	query := `
+		SELECT id, name, era, description, questionnaire_version, answer_vector,
+			deleted, created_at, created_by, updated_at, updated_by
+		FROM philosophers
+		WHERE deleted = false AND questionnaire_version = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(query, version)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return philosophers, nil
+	return scanPhilosophers(rows)
 }
 
 // GetPhilosopherByID IDで哲学者を取得
 func (r *philosopherRepository) GetPhilosopherByID(id int) (*model.Philosopher, error) {
 	query := `
-		SELECT id, name, era, description,
-			answer_01, answer_02, answer_03, answer_04,
-			answer_05, answer_06, answer_07, answer_08,
-			answer_09, answer_10, answer_11, answer_12,
-			answer_13, answer_14, answer_15, answer_16,
+		SELECT id, name, era, description, questionnaire_version, answer_vector,
 			deleted, created_at, created_by, updated_at, updated_by
 		FROM philosophers
 		WHERE id = $1 AND deleted = false`
 
 	p := &model.Philosopher{}
 	err := r.db.QueryRow(query, id).Scan(
-		&p.ID, &p.Name, &p.Era, &p.Description,
-		&p.Answer01, &p.Answer02, &p.Answer03, &p.Answer04,
-		&p.Answer05, &p.Answer06, &p.Answer07, &p.Answer08,
-		&p.Answer09, &p.Answer10, &p.Answer11, &p.Answer12,
-		&p.Answer13, &p.Answer14, &p.Answer15, &p.Answer16,
+		&p.ID, &p.Name, &p.Era, &p.Description, &p.QuestionnaireVersion, &p.Values,
 		&p.Deleted, &p.CreatedAt, &p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy,
 	)
 
@@ -93,3 +84,21 @@ func (r *philosopherRepository) GetPhilosopherByID(id int) (*model.Philosopher,
 
 	return p, nil
 }
+
+// scanPhilosophers 複数行の哲学者データを読み込む
+func scanPhilosophers(rows *sql.Rows) ([]model.Philosopher, error) {
+	philosophers := []model.Philosopher{}
+	for rows.Next() {
+		var p model.Philosopher
+		err := rows.Scan(
+			&p.ID, &p.Name, &p.Era, &p.Description, &p.QuestionnaireVersion, &p.Values,
+			&p.Deleted, &p.CreatedAt, &p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		philosophers = append(philosophers, p)
+	}
+
+	return philosophers, rows.Err()
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// QuestionnaireRepository 質問票データのリポジトリインターフェース
+type QuestionnaireRepository interface {
+	// GetQuestionnaireByVersion バージョンを指定して質問票を取得
+	GetQuestionnaireByVersion(version int) (*model.Questionnaire, error)
+	// GetLatestQuestionnaire 最新バージョンの質問票を取得
+	GetLatestQuestionnaire() (*model.Questionnaire, error)
+}
+
+type questionnaireRepository struct {
+	db *sql.DB
+}
+
+// NewQuestionnaireRepository QuestionnaireRepositoryの新規インスタンスを作成
+func NewQuestionnaireRepository(db *sql.DB) QuestionnaireRepository {
+	return &questionnaireRepository{db: db}
+}
+
+// GetQuestionnaireByVersion バージョンを指定して質問票を取得（質問と選択肢ラベルを含む）
+func (r *questionnaireRepository) GetQuestionnaireByVersion(version int) (*model.Questionnaire, error) {
+	query := `
+		SELECT version, title, description, scale_min, scale_max, balanced_letter, created_at
+		FROM questionnaires
+		WHERE version = $1`
+
+	q := &model.Questionnaire{}
+	err := r.db.QueryRow(query, version).Scan(
+		&q.Version, &q.Title, &q.Description, &q.ScaleMin, &q.ScaleMax, &q.BalancedLetter, &q.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadQuestions(q); err != nil {
+		return nil, err
+	}
+	if err := r.loadScaleLabels(q); err != nil {
+		return nil, err
+	}
+	if err := r.loadAxes(q); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// GetLatestQuestionnaire 最新バージョンの質問票を取得
+func (r *questionnaireRepository) GetLatestQuestionnaire() (*model.Questionnaire, error) {
+	query := `SELECT MAX(version) FROM questionnaires`
+
+	var version sql.NullInt64
+	if err := r.db.QueryRow(query).Scan(&version); err != nil {
+		return nil, err
+	}
+	if !version.Valid {
+		return nil, nil
+	}
+
+	return r.GetQuestionnaireByVersion(int(version.Int64))
+}
+
+// loadQuestions 質問票の質問を位置順に読み込む
+func (r *questionnaireRepository) loadQuestions(q *model.Questionnaire) error {
+	query := `
+		SELECT position, category, text, axis_code, weight, reverse_keyed
+		FROM questionnaire_questions
+		WHERE questionnaire_version = $1
+		ORDER BY position ASC`
+
+	rows, err := r.db.Query(query, q.Version)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	q.Questions = []model.Question{}
+	for rows.Next() {
+		var question model.Question
+		err := rows.Scan(
+			&question.Position, &question.Category, &question.Text,
+			&question.AxisCode, &question.Weight, &question.ReverseKeyed,
+		)
+		if err != nil {
+			return err
+		}
+		q.Questions = append(q.Questions, question)
+	}
+
+	return rows.Err()
+}
+
+// loadScaleLabels 質問票の選択肢ラベルを値の降順に読み込む
+func (r *questionnaireRepository) loadScaleLabels(q *model.Questionnaire) error {
+	query := `
+		SELECT value, label
+		FROM questionnaire_scale_labels
+		WHERE questionnaire_version = $1
+		ORDER BY value DESC`
+
+	rows, err := r.db.Query(query, q.Version)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	q.ScaleLabels = []model.ScaleLabel{}
+	for rows.Next() {
+		var label model.ScaleLabel
+		if err := rows.Scan(&label.Value, &label.Label); err != nil {
+			return err
+		}
+		q.ScaleLabels = append(q.ScaleLabels, label)
+	}
+
+	return rows.Err()
+}
+
+// loadAxes 質問票の軸定義をラベル内の並び順に読み込む
+// label_groupの昇順で"main"の軸が"sub"の軸より先に並ぶ
+func (r *questionnaireRepository) loadAxes(q *model.Questionnaire) error {
+	query := `
+		SELECT code, name, label_group,
+			positive_letter, positive_name, positive_description,
+			negative_letter, negative_name, negative_description, threshold
+		FROM questionnaire_axes
+		WHERE questionnaire_version = $1
+		ORDER BY label_group ASC, position ASC`
+
+	rows, err := r.db.Query(query, q.Version)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	q.Axes = []model.Axis{}
+	for rows.Next() {
+		var axis model.Axis
+		err := rows.Scan(
+			&axis.Code, &axis.Name, &axis.Group,
+			&axis.PositiveLetter, &axis.PositiveName, &axis.PositiveDescription,
+			&axis.NegativeLetter, &axis.NegativeName, &axis.NegativeDescription, &axis.Threshold,
+		)
+		if err != nil {
+			return err
+		}
+		q.Axes = append(q.Axes, axis)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"math"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// AxisBounds 残りの設問への回答次第で軸スコアが取りうる範囲
+// MinとMaxで判定される文字が同じなら、以降の回答でその軸の文字は変わらない
+type AxisBounds struct {
+	Code       string  `json:"code"`
+	Score      float64 `json:"score"` // 現時点で回答を終えた場合のスコア
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	Letter     string  `json:"letter"`
+	Determined bool    `json:"determined"`
+}
+
+// AdaptiveStep 適応型出題の次の1問
+// Doneがtrueの場合、残りの設問に回答してもラベルのどの文字も変わらない
+type AdaptiveStep struct {
+	Done         bool            `json:"done"`
+	NextQuestion *model.Question `json:"next_question"` // Doneの場合はnil
+	Remaining    int             `json:"remaining"`     // まだ出題していない設問の数
+	Axes         []AxisBounds    `json:"axes"`
+	Label        PhiloLabel      `json:"label"` // 現時点の回答から計算したラベル
+}
+
+// NextAdaptiveQuestion これまでの回答から次に出題すべき設問を選ぶ
+// vectorのnilの要素は未回答、skippedは「スキップ / わからない」と回答した設問のインデックス（0始まり）
+// 最も判定が揺れている軸（文字の境界が取りうる範囲の中央に近い軸）の設問のうち、重みが最大のものを選ぶ
+func NextAdaptiveQuestion(vector model.AnswerVector, skipped map[int]bool, questionnaire *model.Questionnaire) AdaptiveStep {
+	step := AdaptiveStep{
+		Axes: make([]AxisBounds, 0, len(questionnaire.Axes)),
+		Label: CalculatePhiloLabel(&model.Answer{
+			QuestionnaireVersion: questionnaire.Version,
+			Values:               vector,
+		}, questionnaire),
+	}
+
+	// まだ出題していない設問（未回答かつスキップしていない設問）
+	pending := make(map[int]bool)
+	for i := range questionnaire.Questions {
+		if !vector.IsAnswered(i) && !skipped[i] {
+			pending[i] = true
+		}
+	}
+	step.Remaining = len(pending)
+
+	nextIndex := -1
+	maxUncertainty := math.Inf(-1)
+	for _, axis := range questionnaire.Axes {
+		bounds := calculateAxisBounds(vector, pending, questionnaire, axis)
+		step.Axes = append(step.Axes, bounds)
+		if bounds.Determined {
+			continue
+		}
+
+		uncertainty := axisUncertainty(bounds, questionnaire, axis, AxisMaxScore(questionnaire, axis.Code))
+		if uncertainty <= maxUncertainty {
+			continue
+		}
+		if idx := heaviestPendingQuestion(questionnaire, axis.Code, pending); idx >= 0 {
+			maxUncertainty = uncertainty
+			nextIndex = idx
+		}
+	}
+
+	if nextIndex < 0 {
+		step.Done = true
+		return step
+	}
+
+	question := questionnaire.Questions[nextIndex]
+	step.NextQuestion = &question
+	return step
+}
+
+// calculateAxisBounds 未出題の設問への回答次第で軸スコアが取りうる範囲を計算
+// 軸スコアは回答済みの設問の得点を重みの比率で換算した値（CalculateAxisScore）なので、
+// 未出題の設問をすべて一方の端で回答した場合に最大・最小となる（途中で回答をやめた場合はその間に収まる）
+func calculateAxisBounds(vector model.AnswerVector, pending map[int]bool, questionnaire *model.Questionnaire, axis model.Axis) AxisBounds {
+	score, _ := CalculateAxisScore(vector, questionnaire, axis.Code)
+
+	var sum, answeredWeight, pendingWeight, totalWeight float64
+	for _, idx := range questionnaire.AxisQuestionIndexes(axis.Code) {
+		weight := questionnaire.Questions[idx].Weight
+		totalWeight += weight
+		switch {
+		case vector.IsAnswered(idx):
+			sum += questionnaire.ScoredValue(idx, *vector[idx])
+			answeredWeight += weight
+		case pending[idx]:
+			pendingWeight += weight
+		}
+	}
+
+	minScore, maxScore := score, score
+	if weight := answeredWeight + pendingWeight; weight > 0 && pendingWeight > 0 {
+		swing := pendingWeight * questionnaire.ScaleHalfRange()
+		maxScore = math.Max(score, (sum+swing)*totalWeight/weight)
+		minScore = math.Min(score, (sum-swing)*totalWeight/weight)
+	}
+
+	// バランス型の範囲は閾値の前後に連続しているため、両端の文字が同じなら途中の文字も同じ
+	limit := AxisMaxScore(questionnaire, axis.Code)
+	letter, _ := judgeAxis(questionnaire, axis, score, limit)
+	minLetter, _ := judgeAxis(questionnaire, axis, minScore, limit)
+	maxLetter, _ := judgeAxis(questionnaire, axis, maxScore, limit)
+
+	return AxisBounds{
+		Code:       axis.Code,
+		Score:      score,
+		Min:        minScore,
+		Max:        maxScore,
+		Letter:     letter,
+		Determined: minLetter == maxLetter,
+	}
+}
+
+// axisUncertainty 文字が決まっていない軸の判定の揺れやすさ（0〜0.5）
+// 取りうる範囲の内側にある文字の境界のうち、範囲の中央に最も近い境界について、
+// 範囲の端までの近い方の距離を範囲の幅に対する割合で返す（境界が範囲の端に近いほど、残りの回答で文字が変わる余地は小さい）
+func axisUncertainty(bounds AxisBounds, questionnaire *model.Questionnaire, axis model.Axis, maxScore float64) float64 {
+	width := bounds.Max - bounds.Min
+	if width <= 0 {
+		return 0
+	}
+
+	uncertainty := 0.0
+	for _, boundary := range letterBoundaries(questionnaire, axis, maxScore) {
+		if boundary < bounds.Min || boundary > bounds.Max {
+			continue
+		}
+		uncertainty = math.Max(uncertainty, math.Min(bounds.Max-boundary, boundary-bounds.Min)/width)
+	}
+	return uncertainty
+}
+
+// letterBoundaries 軸の文字が切り替わるスコア（judgeAxisと対応）
+// 質問票のBalancedLetterがtrueならバランス型の範囲の下端と上端、falseなら閾値
+func letterBoundaries(questionnaire *model.Questionnaire, axis model.Axis, maxScore float64) []float64 {
+	if !questionnaire.BalancedLetter {
+		return []float64{axis.Threshold}
+	}
+	return []float64{
+		axis.Threshold - (maxScore+axis.Threshold)*balancedStrength/100,
+		axis.Threshold + (maxScore-axis.Threshold)*balancedStrength/100,
+	}
+}
+
+// heaviestPendingQuestion 軸に属する未出題の設問のうち、重みが最大の設問のインデックスを返す（同じ重みなら設問順）
+// 未出題の設問がなければ-1を返す
+func heaviestPendingQuestion(questionnaire *model.Questionnaire, axisCode string, pending map[int]bool) int {
+	best := -1
+	for _, idx := range questionnaire.AxisQuestionIndexes(axisCode) {
+		if !pending[idx] {
+			continue
+		}
+		if best < 0 || questionnaire.Questions[idx].Weight > questionnaire.Questions[best].Weight {
+			best = idx
+		}
+	}
+	return best
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// testValue 回答値のポインタ
+func testValue(v int16) *int16 {
+	return &v
+}
+
+// testAxis テスト用の軸（正の極は"S"、負の極は"N"）
+func testAxis(code string, threshold float64) model.Axis {
+	return model.Axis{
+		Code:           code,
+		Name:           code,
+		Group:          model.AxisGroupMain,
+		PositiveLetter: "S",
+		PositiveName:   "positive",
+		NegativeLetter: "N",
+		NegativeName:   "negative",
+		Threshold:      threshold,
+	}
+}
+
+// testQuestion テスト用の設問
+func testQuestion(axisCode string, weight float64) model.Question {
+	return model.Question{AxisCode: &axisCode, Weight: weight}
+}
+
+// newTestQuestionnaire -2〜2のスケールのテスト用の質問票（設問の位置は並び順で設定する）
+func newTestQuestionnaire(axes []model.Axis, questions ...model.Question) *model.Questionnaire {
+	for i := range questions {
+		questions[i].Position = i + 1
+	}
+	return &model.Questionnaire{
+		Version:   1,
+		ScaleMin:  -2,
+		ScaleMax:  2,
+		Axes:      axes,
+		Questions: questions,
+	}
+}
+
+func TestNextAdaptiveQuestion(t *testing.T) {
+	twoAxes := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0), testAxis("b", 0)},
+		testQuestion("a", 1), testQuestion("a", 2), testQuestion("b", 1), testQuestion("b", 3),
+	)
+	// バランス型の範囲にかかるだけで、閾値は取りうる範囲の外にある軸
+	balancedOnly := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 0.35), testQuestion("a", 1), testQuestion("a", 0.05),
+	)
+	balancedOnly.BalancedLetter = true
+	poleOnly := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 0.35), testQuestion("a", 1), testQuestion("a", 0.05),
+	)
+	determined := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 1), testQuestion("a", 1), testQuestion("a", 1),
+	)
+
+	tests := []struct {
+		name          string
+		questionnaire *model.Questionnaire
+		vector        model.AnswerVector
+		skipped       map[int]bool
+		wantDone      bool
+		wantNext      int // 次の設問の位置（Doneの場合は0）
+		wantRemaining int
+	}{
+		{
+			name:          "未回答なら最初の軸の重みが最大の設問",
+			questionnaire: twoAxes,
+			vector:        make(model.AnswerVector, 4),
+			wantNext:      2,
+			wantRemaining: 4,
+		},
+		{
+			name:          "文字が決まった軸は出題しない",
+			questionnaire: twoAxes,
+			vector:        model.AnswerVector{testValue(2), testValue(2), nil, nil},
+			wantNext:      4,
+			wantRemaining: 2,
+		},
+		{
+			name:          "スキップした設問は出題しない",
+			questionnaire: twoAxes,
+			vector:        model.AnswerVector{testValue(2), testValue(2), nil, nil},
+			skipped:       map[int]bool{3: true},
+			wantNext:      3,
+			wantRemaining: 1,
+		},
+		{
+			name:          "バランス型の範囲をまたぐだけの軸も出題する",
+			questionnaire: balancedOnly,
+			vector:        model.AnswerVector{testValue(-1), testValue(0), nil},
+			wantNext:      3,
+			wantRemaining: 1,
+		},
+		{
+			name:          "バランス型を\"X\"で表さない質問票では閾値だけで判定",
+			questionnaire: poleOnly,
+			vector:        model.AnswerVector{testValue(-1), testValue(0), nil},
+			wantDone:      true,
+			wantRemaining: 1,
+		},
+		{
+			name:          "残りの回答で文字が変わらなければ終了",
+			questionnaire: determined,
+			vector:        model.AnswerVector{testValue(2), testValue(2), nil},
+			wantDone:      true,
+			wantRemaining: 1,
+		},
+		{
+			name:          "すべて回答済みなら終了",
+			questionnaire: determined,
+			vector:        model.AnswerVector{testValue(0), testValue(1), testValue(-1)},
+			wantDone:      true,
+			wantRemaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := NextAdaptiveQuestion(tt.vector, tt.skipped, tt.questionnaire)
+
+			if step.Done != tt.wantDone {
+				t.Fatalf("Done = %v, want %v (axes: %+v)", step.Done, tt.wantDone, step.Axes)
+			}
+			if step.Remaining != tt.wantRemaining {
+				t.Errorf("Remaining = %d, want %d", step.Remaining, tt.wantRemaining)
+			}
+			if tt.wantDone {
+				if step.NextQuestion != nil {
+					t.Errorf("NextQuestion = %+v, want nil", step.NextQuestion)
+				}
+				return
+			}
+			if step.NextQuestion == nil || step.NextQuestion.Position != tt.wantNext {
+				t.Errorf("NextQuestion = %+v, want position %d", step.NextQuestion, tt.wantNext)
+			}
+		})
+	}
+}
+
+// 終了と判定した場合は、残りの設問にどう回答してもラベルが変わらないこと
+// 終了していない場合は、出題した設問への回答次第でラベルが変わりうること
+func TestNextAdaptiveQuestionStopsOnlyWhenLabelIsFixed(t *testing.T) {
+	for _, balancedLetter := range []bool{true, false} {
+		questionnaire := newTestQuestionnaire(
+			[]model.Axis{testAxis("a", 0)},
+			testQuestion("a", 0.35), testQuestion("a", 1), testQuestion("a", 0.05),
+		)
+		questionnaire.BalancedLetter = balancedLetter
+
+		for _, first := range []int16{-2, -1, 0, 1, 2} {
+			for _, second := range []int16{-2, -1, 0, 1, 2} {
+				vector := model.AnswerVector{testValue(first), testValue(second), nil}
+				step := NextAdaptiveQuestion(vector, nil, questionnaire)
+
+				labels := make(map[string]bool)
+				for v := questionnaire.ScaleMin; v <= questionnaire.ScaleMax; v++ {
+					answered := model.AnswerVector{testValue(first), testValue(second), testValue(v)}
+					labels[CalculatePhiloLabel(&model.Answer{Values: answered}, questionnaire).MainLabel] = true
+				}
+				// 最後の設問をスキップした場合
+				labels[step.Label.MainLabel] = true
+
+				if step.Done && len(labels) > 1 {
+					t.Errorf("balanced letter %v, answers %d, %d: Done but label can still be one of %v", balancedLetter, first, second, labels)
+				}
+				if !step.Done && len(labels) == 1 {
+					t.Errorf("balanced letter %v, answers %d, %d: not Done but label is fixed to %v", balancedLetter, first, second, labels)
+				}
+			}
+		}
+	}
+}
+
+func TestCalculateAxisBounds(t *testing.T) {
+	questionnaire := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 0.35), testQuestion("a", 1), testQuestion("a", 0.05),
+	)
+	questionnaire.BalancedLetter = true
+
+	tests := []struct {
+		name           string
+		vector         model.AnswerVector
+		pending        map[int]bool
+		wantScore      float64
+		wantMin        float64
+		wantMax        float64
+		wantLetter     string
+		wantDetermined bool
+	}{
+		{
+			name:       "未回答なら両端まで",
+			vector:     make(model.AnswerVector, 3),
+			pending:    map[int]bool{0: true, 1: true, 2: true},
+			wantScore:  0,
+			wantMin:    -2.8,
+			wantMax:    2.8,
+			wantLetter: BalancedLetter,
+		},
+		{
+			name:       "回答済みの設問の重みの比率で換算",
+			vector:     model.AnswerVector{testValue(-1), testValue(0), nil},
+			pending:    map[int]bool{2: true},
+			wantScore:  -0.35 * 1.4 / 1.35,
+			wantMin:    -0.45,
+			wantMax:    -0.25,
+			wantLetter: "N",
+		},
+		{
+			name:           "スキップした設問は範囲に含めない",
+			vector:         model.AnswerVector{testValue(-1), testValue(-2), nil},
+			pending:        map[int]bool{},
+			wantScore:      -2.35 * 1.4 / 1.35,
+			wantMin:        -2.35 * 1.4 / 1.35,
+			wantMax:        -2.35 * 1.4 / 1.35,
+			wantLetter:     "N",
+			wantDetermined: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds := calculateAxisBounds(tt.vector, tt.pending, questionnaire, questionnaire.Axes[0])
+
+			for _, c := range []struct {
+				field     string
+				got, want float64
+			}{
+				{"Score", bounds.Score, tt.wantScore},
+				{"Min", bounds.Min, tt.wantMin},
+				{"Max", bounds.Max, tt.wantMax},
+			} {
+				if math.Abs(c.got-c.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", c.field, c.got, c.want)
+				}
+			}
+			if bounds.Letter != tt.wantLetter {
+				t.Errorf("Letter = %q, want %q", bounds.Letter, tt.wantLetter)
+			}
+			if bounds.Determined != tt.wantDetermined {
+				t.Errorf("Determined = %v, want %v", bounds.Determined, tt.wantDetermined)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// AnswerIndex 近傍の回答数を数えるためのプロセス内の回答ベクトルのインデックス
+// 起動時にすべての回答を読み込み、回答の保存に成功するたびに追加する
+// 回答値は離散的で同じベクトルの回答が多いため、同じベクトルの回答をまとめ、距離はまとまりごとに1回だけ計算する
+// DBを直接更新した場合や複数のプロセスで動かす場合は、再起動するまでDBの内容と一致しない
+type AnswerIndex struct {
+	mu      sync.RWMutex
+	answers []*model.Answer                  // 古い順
+	buckets map[int]map[string]*vectorBucket // 質問票のバージョン => ベクトルのキー => 同じベクトルの回答
+}
+
+// vectorBucket 同じ回答ベクトルを持つ回答のまとまり
+type vectorBucket struct {
+	vector  model.AnswerVector
+	answers []*model.Answer
+}
+
+// NewAnswerIndex 回答の一覧からインデックスを作成（順序は問わない）
+func NewAnswerIndex(answers []model.Answer) *AnswerIndex {
+	sorted := make([]model.Answer, len(answers))
+	copy(sorted, answers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	index := &AnswerIndex{
+		answers: make([]*model.Answer, 0, len(sorted)),
+		buckets: make(map[int]map[string]*vectorBucket),
+	}
+	for i := range sorted {
+		index.add(&sorted[i])
+	}
+	return index
+}
+
+// Add 保存した回答をインデックスに追加
+func (x *AnswerIndex) Add(answer model.Answer) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.add(&answer)
+}
+
+func (x *AnswerIndex) add(answer *model.Answer) {
+	x.answers = append(x.answers, answer)
+
+	buckets, ok := x.buckets[answer.QuestionnaireVersion]
+	if !ok {
+		buckets = make(map[string]*vectorBucket)
+		x.buckets[answer.QuestionnaireVersion] = buckets
+	}
+	vector := answer.ToVector()
+	key := vectorKey(vector)
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = &vectorBucket{vector: vector}
+		buckets[key] = bucket
+	}
+	bucket.answers = append(bucket.answers, answer)
+}
+
+// LinkToUser 匿名回答をユーザーに紐づけたことを反映（AnswerRepository.LinkAnswerToUserの成功後に呼ぶ）
+func (x *AnswerIndex) LinkToUser(answerID, userID int) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, answer := range x.answers {
+		if answer.ID == answerID && answer.UserID == nil {
+			answer.UserID = &userID
+			return
+		}
+	}
+}
+
+// Find 絞り込み条件に一致する回答を新しい順に返す（AnswerRepository.FindAnswersと同じ条件）
+func (x *AnswerIndex) Find(filter model.AnswerFilter) []model.Answer {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	result := []model.Answer{}
+	for _, answer := range x.population(filter) {
+		result = append(result, *answer)
+	}
+	return result
+}
+
+// population 絞り込み条件に一致する回答を新しい順に返す
+// LatestPerRespondentの場合は、回答者ごとに最初に見つかった（最新の）回答のみ
+func (x *AnswerIndex) population(filter model.AnswerFilter) []*model.Answer {
+	result := []*model.Answer{}
+	seen := make(map[string]bool)
+	for i := len(x.answers) - 1; i >= 0; i-- {
+		answer := x.answers[i]
+		if !answerMatchesFilter(answer, filter) {
+			continue
+		}
+		if filter.LatestPerRespondent {
+			key := answer.RespondentKey()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		result = append(result, answer)
+	}
+	return result
+}
+
+// Neighbors 絞り込み条件に一致する回答のうち、各半径以内にある回答の数とk番目に近い回答者までの距離を計算
+// 同じベクトルの回答との距離は1回だけ計算し、すべての半径を1回の走査で数える
+// excludeAnswerIDの回答（近傍を調べる回答自身）は母集団から除く（保存していない回答の場合はnil）
+func (x *AnswerIndex) Neighbors(target model.AnswerVector, filter model.AnswerFilter, metric DistanceMetric, radii []float64, k int, excludeAnswerID *int) NeighborStats {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// 母集団に含まれる回答（1人1票の場合は回答者ごとの最新の回答）
+	included := make(map[*model.Answer]bool)
+	for _, answer := range x.population(filter) {
+		if excludeAnswerID == nil || answer.ID != *excludeAnswerID {
+			included[answer] = true
+		}
+	}
+
+	distances := []neighborDistance{}
+	for version, buckets := range x.buckets {
+		if filter.QuestionnaireVersion != nil && *filter.QuestionnaireVersion != version {
+			continue
+		}
+		for _, bucket := range buckets {
+			matched := 0
+			for _, answer := range bucket.answers {
+				if included[answer] {
+					matched++
+				}
+			}
+			if matched == 0 {
+				continue
+			}
+			distances = append(distances, neighborDistance{distance: metric.Distance(target, bucket.vector), count: matched})
+		}
+	}
+	return summarizeNeighbors(distances, radii, k)
+}
+
+// answerMatchesFilter 回答が絞り込み条件に一致するか（回答時間・ロケール等が記録されていない回答は、その条件を指定した場合は一致しない）
+func answerMatchesFilter(answer *model.Answer, filter model.AnswerFilter) bool {
+	switch {
+	case filter.QuestionnaireVersion != nil && answer.QuestionnaireVersion != *filter.QuestionnaireVersion:
+		return false
+	case filter.MinDurationMs != nil && (answer.DurationMs == nil || *answer.DurationMs < *filter.MinDurationMs):
+		return false
+	case filter.MaxDurationMs != nil && (answer.DurationMs == nil || *answer.DurationMs > *filter.MaxDurationMs):
+		return false
+	case filter.Locale != nil && (answer.Locale == nil || *answer.Locale != *filter.Locale):
+		return false
+	case filter.ClientVersion != nil && (answer.ClientVersion == nil || *answer.ClientVersion != *filter.ClientVersion):
+		return false
+	case !filter.IncludeFlagged && answer.Quality.Flagged:
+		return false
+	case filter.CreatedFrom != nil && answer.CreatedAt.Before(*filter.CreatedFrom):
+		return false
+	case filter.CreatedTo != nil && !answer.CreatedAt.Before(*filter.CreatedTo):
+		return false
+	}
+	return true
+}
+
+// vectorKey 回答ベクトルを同じベクトルの判定に使う文字列に変換（スキップは"_"）
+func vectorKey(vector model.AnswerVector) string {
+	var b strings.Builder
+	for i, value := range vector {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if value == nil {
+			b.WriteByte('_')
+			continue
+		}
+		b.WriteString(strconv.Itoa(int(*value)))
+	}
+	return b.String()
+}
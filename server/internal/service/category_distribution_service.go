@@ -1,6 +1,10 @@
 package service
 
-import "github.com/HH19xx/philoCompass/internal/model"
+import (
+	"math"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
 
 // CategoryDistribution カテゴリごとのスコア分布
 type CategoryDistribution struct {
@@ -8,52 +12,53 @@ type CategoryDistribution struct {
 	Count int `json:"count"`
 }
 
-// AllCategoryDistributions 全カテゴリの分布データ
-type AllCategoryDistributions struct {
-	// 構造体タグ: JSON変換時のフィールド名を指定（GoのScoreフィールドがJSONの"score"になる）
-	Logic      []CategoryDistribution `json:"logic"`
-	Ethics     []CategoryDistribution `json:"ethics"`
-	Aesthetics []CategoryDistribution `json:"aesthetics"`
-	Postmodern []CategoryDistribution `json:"postmodern"`
-}
+// AllCategoryDistributions 全軸の分布データ（軸コード => 分布）
+// 例: {"logic": [{"score": -6, "count": 0}, ...], "ethics": [...]}
+type AllCategoryDistributions map[string][]CategoryDistribution
 
-// CalculateCategoryDistributions 全ユーザーの各カテゴリスコア分布を計算
-func CalculateCategoryDistributions(answers []*model.Answer) AllCategoryDistributions {
-	// -6 ~ +6の各スコアの出現回数を初期化
-	logicMap := make(map[int16]int)
-	ethicsMap := make(map[int16]int)
-	aestheticsMap := make(map[int16]int)
-	postmodernMap := make(map[int16]int)
+// CalculateCategoryDistributions 全ユーザーの各軸のスコア分布を計算
+// answersはすべてquestionnaireに対する回答であること
+// 重み付きのスコアは最も近い整数の階級に集計する
+// 軸の設問をすべてスキップした回答は、その軸の分布には含めない
+func CalculateCategoryDistributions(answers []*model.Answer, questionnaire *model.Questionnaire) AllCategoryDistributions {
+	// 軸ごとに各スコアの出現回数を初期化
+	scoreMaps := make(map[string]map[int]int, len(questionnaire.Axes))
+	for _, axis := range questionnaire.Axes {
+		scoreMaps[axis.Code] = make(map[int]int)
+	}
 
-	// 各ユーザーのカテゴリスコアを集計
+	// 各ユーザーの軸スコアを集計
 	for _, answer := range answers {
-		logicScore := answer.Answer01 + answer.Answer02 + answer.Answer03
-		ethicsScore := answer.Answer04 + answer.Answer05 + answer.Answer06
-		aestheticsScore := answer.Answer07 + answer.Answer08 + answer.Answer09
-		postmodernScore := answer.Answer10 + answer.Answer11 + answer.Answer12
-
-		logicMap[logicScore]++
-		ethicsMap[ethicsScore]++
-		aestheticsMap[aestheticsScore]++
-		postmodernMap[postmodernScore]++
+		vector := answer.ToVector()
+		for _, axis := range questionnaire.Axes {
+			score, answered := CalculateAxisScore(vector, questionnaire, axis.Code)
+			if answered == 0 {
+				continue
+			}
+			scoreMaps[axis.Code][int(math.Round(score))]++
+		}
 	}
 
-	// -6 ~ +6の全スコアについてデータを生成（カウント0も含む）
-	return AllCategoryDistributions{
-		Logic:      buildDistribution(logicMap),
-		Ethics:     buildDistribution(ethicsMap),
-		Aesthetics: buildDistribution(aestheticsMap),
-		Postmodern: buildDistribution(postmodernMap),
+	// 取りうる全スコアについてデータを生成（カウント0も含む）
+	result := make(AllCategoryDistributions, len(questionnaire.Axes))
+	for _, axis := range questionnaire.Axes {
+		result[axis.Code] = buildDistribution(scoreMaps[axis.Code], axisMaxScore(questionnaire, axis.Code))
 	}
+	return result
+}
+
+// axisMaxScore 分布の階級の範囲（軸スコアの最大絶対値を切り上げた整数）
+func axisMaxScore(questionnaire *model.Questionnaire, axisCode string) int {
+	return int(math.Ceil(AxisMaxScore(questionnaire, axisCode)))
 }
 
-// buildDistribution -6 ~ +6の全スコアについてCategoryDistributionを生成
-func buildDistribution(scoreMap map[int16]int) []CategoryDistribution {
-	result := make([]CategoryDistribution, 0, 13)
-	for score := int16(-6); score <= 6; score++ {
+// buildDistribution -maxScore ~ +maxScoreの全スコアについてCategoryDistributionを生成
+func buildDistribution(scoreMap map[int]int, maxScore int) []CategoryDistribution {
+	result := make([]CategoryDistribution, 0, 2*maxScore+1)
+	for score := -maxScore; score <= maxScore; score++ {
 		count := scoreMap[score]
 		result = append(result, CategoryDistribution{
-			Score: int(score),
+			Score: score,
 			Count: count,
 		})
 	}
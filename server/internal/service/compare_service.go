@@ -0,0 +1,96 @@
+package service
+
+import (
+	"math"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// QuestionDiff 設問ごとの回答の差
+type QuestionDiff struct {
+	Position   int     `json:"position"` // 設問の位置（1始まり）
+	AxisCode   *string `json:"axis_code,omitempty"`
+	A          *int16  `json:"a"` // nilはスキップ
+	B          *int16  `json:"b"`
+	Difference *int16  `json:"difference"` // B - A（どちらかがスキップした場合はnil）
+}
+
+// AxisDelta 軸ごとのスコアの差とラベルの文字の違い
+type AxisDelta struct {
+	Code    string  `json:"code"`
+	Name    string  `json:"name"`
+	Group   string  `json:"group"`
+	ScoreA  float64 `json:"score_a"`
+	ScoreB  float64 `json:"score_b"`
+	Delta   float64 `json:"delta"` // ScoreB - ScoreA
+	LetterA string  `json:"letter_a"`
+	LetterB string  `json:"letter_b"`
+	Differs bool    `json:"differs"` // ラベルの文字が異なる
+}
+
+// AnswerComparison 2つの回答の比較結果
+type AnswerComparison struct {
+	Distance      *float64       `json:"distance"` // 共通して回答した設問がない場合はnil
+	LabelA        PhiloLabel     `json:"label_a"`
+	LabelB        PhiloLabel     `json:"label_b"`
+	DifferingAxes []string       `json:"differing_axes"` // ラベルの文字が異なる軸のコード
+	Axes          []AxisDelta    `json:"axes"`
+	Questions     []QuestionDiff `json:"questions"`
+}
+
+// CompareAnswers 同じ質問票に対する2つの回答を比較
+// 距離は近傍の回答数や哲学者との比較の既定と同じ重み付きユークリッド距離
+func CompareAnswers(a, b *model.Answer, questionnaire *model.Questionnaire) AnswerComparison {
+	vectorA := a.ToVector()
+	vectorB := b.ToVector()
+
+	comparison := AnswerComparison{
+		LabelA:        CalculatePhiloLabel(a, questionnaire),
+		LabelB:        CalculatePhiloLabel(b, questionnaire),
+		DifferingAxes: []string{},
+		Questions:     make([]QuestionDiff, 0, len(questionnaire.Questions)),
+	}
+
+	if distance := CalculateEuclideanDistance(vectorA, vectorB, questionnaire.Weights()); !math.IsInf(distance, 1) {
+		comparison.Distance = &distance
+	}
+
+	// 設問ごとの差
+	for i, question := range questionnaire.Questions {
+		diff := QuestionDiff{Position: question.Position, AxisCode: question.AxisCode}
+		if vectorA.IsAnswered(i) {
+			diff.A = vectorA[i]
+		}
+		if vectorB.IsAnswered(i) {
+			diff.B = vectorB[i]
+		}
+		if diff.A != nil && diff.B != nil {
+			d := *diff.B - *diff.A
+			diff.Difference = &d
+		}
+		comparison.Questions = append(comparison.Questions, diff)
+	}
+
+	// 軸ごとのスコアの差（ラベルの軸は両方とも質問票の軸と同じ順序）
+	comparison.Axes = make([]AxisDelta, 0, len(comparison.LabelA.Axes))
+	for i, axisA := range comparison.LabelA.Axes {
+		axisB := comparison.LabelB.Axes[i]
+		delta := AxisDelta{
+			Code:    axisA.Code,
+			Name:    axisA.Name,
+			Group:   axisA.Group,
+			ScoreA:  axisA.Score,
+			ScoreB:  axisB.Score,
+			Delta:   axisB.Score - axisA.Score,
+			LetterA: axisA.Letter,
+			LetterB: axisB.Letter,
+			Differs: axisA.Letter != axisB.Letter,
+		}
+		if delta.Differs {
+			comparison.DifferingAxes = append(comparison.DifferingAxes, delta.Code)
+		}
+		comparison.Axes = append(comparison.Axes, delta)
+	}
+
+	return comparison
+}
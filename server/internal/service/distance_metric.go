@@ -0,0 +1,407 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// 距離指標の名前（クエリパラメータmetricで指定する値）
+const (
+	MetricEuclidean   = "euclidean"
+	MetricManhattan   = "manhattan"
+	MetricCosine      = "cosine"
+	MetricChebyshev   = "chebyshev"
+	MetricMahalanobis = "mahalanobis"
+)
+
+// DistanceMetrics 指定できる距離指標の一覧
+var DistanceMetrics = []string{MetricEuclidean, MetricManhattan, MetricCosine, MetricChebyshev, MetricMahalanobis}
+
+// mahalanobisRidge 共分散行列の対角に加える正則化項（スケールの中央から端までの幅の2乗に対する比率）
+// 回答数が少ない、すべて同じ値の設問がある等で共分散行列が特異になる場合でも逆行列が存在するようにする
+const mahalanobisRidge = 0.1
+
+// DistanceMetric 回答ベクトル間の距離指標
+// どの指標でも、どちらかがスキップした設問は除外し、両者が回答した設問だけで比較する
+// 次元数が異なる、または共通して回答した設問がないベクトルは比較できないため無限大を返す
+type DistanceMetric interface {
+	// Name 距離指標の名前（MetricEuclidean など）
+	Name() string
+	// Distance 2つの回答ベクトル間の距離
+	Distance(v1, v2 model.AnswerVector) float64
+	// MaxDistance 質問票で取りうる最大の距離（類似度の正規化に使う）
+	MaxDistance() float64
+}
+
+// NewDistanceMetric 名前から距離指標を作成（空文字の場合はユークリッド距離）
+// マハラノビス距離はpopulation（同じ質問票に対する回答の母集団）の共分散から作成する
+func NewDistanceMetric(name string, questionnaire *model.Questionnaire, population []model.Answer) (DistanceMetric, error) {
+	switch name {
+	case "", MetricEuclidean:
+		return newEuclideanMetric(questionnaire), nil
+	case MetricManhattan:
+		return newManhattanMetric(questionnaire), nil
+	case MetricCosine:
+		return &cosineMetric{weights: questionnaire.Weights(), midpoint: questionnaire.ScaleMidpoint()}, nil
+	case MetricChebyshev:
+		return newChebyshevMetric(questionnaire), nil
+	case MetricMahalanobis:
+		return newMahalanobisMetric(questionnaire, population), nil
+	}
+	return nil, fmt.Errorf("metric must be one of %s", strings.Join(DistanceMetrics, ", "))
+}
+
+// MetricNeedsPopulation 距離指標の作成に回答の母集団が必要かどうか
+func MetricNeedsPopulation(name string) bool {
+	return name == MetricMahalanobis
+}
+
+// euclideanMetric 重み付きユークリッド距離（CalculateEuclideanDistance）
+type euclideanMetric struct {
+	weights     []float64
+	maxDistance float64
+}
+
+func newEuclideanMetric(questionnaire *model.Questionnaire) *euclideanMetric {
+	return &euclideanMetric{weights: questionnaire.Weights(), maxDistance: maxEuclideanDistance(questionnaire)}
+}
+
+func (m *euclideanMetric) Name() string { return MetricEuclidean }
+
+func (m *euclideanMetric) Distance(v1, v2 model.AnswerVector) float64 {
+	return CalculateEuclideanDistance(v1, v2, m.weights)
+}
+
+func (m *euclideanMetric) MaxDistance() float64 { return m.maxDistance }
+
+// manhattanMetric 重み付きマンハッタン距離（差の絶対値の重み付き和）
+// ユークリッド距離と同様に、両者が回答した設問の重みの比率で全設問分に換算する
+type manhattanMetric struct {
+	weights     []float64
+	maxDistance float64
+}
+
+func newManhattanMetric(questionnaire *model.Questionnaire) *manhattanMetric {
+	scaleWidth := float64(questionnaire.ScaleMax) - float64(questionnaire.ScaleMin)
+	m := &manhattanMetric{weights: questionnaire.Weights()}
+	for _, w := range m.weights {
+		m.maxDistance += w * scaleWidth
+	}
+	return m
+}
+
+func (m *manhattanMetric) Name() string { return MetricManhattan }
+
+func (m *manhattanMetric) Distance(v1, v2 model.AnswerVector) float64 {
+	if len(v1) != len(v2) {
+		return math.Inf(1)
+	}
+
+	var sum, answeredWeight, totalWeight float64
+	for i := range v1 {
+		w := weightAt(m.weights, i)
+		totalWeight += w
+		if v1[i] == nil || v2[i] == nil {
+			continue
+		}
+		sum += w * math.Abs(float64(*v1[i]-*v2[i]))
+		answeredWeight += w
+	}
+	if answeredWeight == 0 {
+		return math.Inf(1)
+	}
+	return sum * totalWeight / answeredWeight
+}
+
+func (m *manhattanMetric) MaxDistance() float64 { return m.maxDistance }
+
+// chebyshevMetric 重み付きチェビシェフ距離（最も意見が分かれた設問の差）
+// ユークリッド距離と同じ尺度になるよう、差には重みの平方根を掛ける
+// 最大値をとるだけなので、スキップした設問の分の換算は行わない
+type chebyshevMetric struct {
+	weights     []float64
+	maxDistance float64
+}
+
+func newChebyshevMetric(questionnaire *model.Questionnaire) *chebyshevMetric {
+	scaleWidth := float64(questionnaire.ScaleMax) - float64(questionnaire.ScaleMin)
+	m := &chebyshevMetric{weights: questionnaire.Weights()}
+	for _, w := range m.weights {
+		m.maxDistance = math.Max(m.maxDistance, math.Sqrt(w)*scaleWidth)
+	}
+	return m
+}
+
+func (m *chebyshevMetric) Name() string { return MetricChebyshev }
+
+func (m *chebyshevMetric) Distance(v1, v2 model.AnswerVector) float64 {
+	if len(v1) != len(v2) {
+		return math.Inf(1)
+	}
+
+	distance, answered := 0.0, false
+	for i := range v1 {
+		if v1[i] == nil || v2[i] == nil {
+			continue
+		}
+		distance = math.Max(distance, math.Sqrt(weightAt(m.weights, i))*math.Abs(float64(*v1[i]-*v2[i])))
+		answered = true
+	}
+	if !answered {
+		return math.Inf(1)
+	}
+	return distance
+}
+
+func (m *chebyshevMetric) MaxDistance() float64 { return m.maxDistance }
+
+// cosineMetric コサイン距離（1 - コサイン類似度、0〜2）
+// 回答はスケールの中央を原点とした値で比較するため、意見の強さではなく向きが近いほど距離が小さくなる
+// （穏やかな意見の回答者でも、同じ向きの強い意見を持つ哲学者と近くなる）
+type cosineMetric struct {
+	weights  []float64
+	midpoint float64
+}
+
+func (m *cosineMetric) Name() string { return MetricCosine }
+
+// Distance どちらかの共通して回答した設問がすべて中央の値の場合は向きがないため、直交とみなして1を返す
+func (m *cosineMetric) Distance(v1, v2 model.AnswerVector) float64 {
+	if len(v1) != len(v2) {
+		return math.Inf(1)
+	}
+
+	var dot, norm1, norm2 float64
+	answered := false
+	for i := range v1 {
+		if v1[i] == nil || v2[i] == nil {
+			continue
+		}
+		w := weightAt(m.weights, i)
+		a := float64(*v1[i]) - m.midpoint
+		b := float64(*v2[i]) - m.midpoint
+		dot += w * a * b
+		norm1 += w * a * a
+		norm2 += w * b * b
+		answered = true
+	}
+	if !answered {
+		return math.Inf(1)
+	}
+	if norm1 == 0 || norm2 == 0 {
+		return 1
+	}
+	// 浮動小数点の誤差で範囲外にならないように丸める
+	return 1 - math.Max(-1, math.Min(1, dot/math.Sqrt(norm1*norm2)))
+}
+
+func (m *cosineMetric) MaxDistance() float64 { return 2 }
+
+// mahalanobisMetric 回答の母集団の共分散で補正したマハラノビス距離
+// 相関の強い設問の組（同じ考え方を別の聞き方で尋ねた設問など）の差を二重に数えず、
+// ばらつきの小さい設問の差を大きく評価する（設問ごとの重みの代わりに母集団の共分散を使う）
+// スキップした設問を含む組は、両者が回答した設問の共分散行列の逆行列で計算し、設問数の比率で全設問分に換算する
+type mahalanobisMetric struct {
+	covariance  [][]float64
+	maxDistance float64
+
+	mu       sync.Mutex
+	inverses map[string][][]float64 // 回答した設問の組み合わせ => 共分散行列の逆行列
+}
+
+// newMahalanobisMetric 母集団の共分散行列を推定してマハラノビス距離を作成
+// スキップされた値は設問の平均で補完して共分散を推定する
+// 母集団が2件未満の場合は正則化項のみとなり、重みなしのユークリッド距離に比例する
+func newMahalanobisMetric(questionnaire *model.Questionnaire, population []model.Answer) *mahalanobisMetric {
+	n := len(questionnaire.Questions)
+
+	// 設問ごとの平均
+	means := make([]float64, n)
+	counts := make([]int, n)
+	vectors := make([]model.AnswerVector, 0, len(population))
+	for _, answer := range population {
+		vector := answer.ToVector()
+		if len(vector) != n {
+			continue
+		}
+		vectors = append(vectors, vector)
+		for i, value := range vector {
+			if value != nil {
+				means[i] += float64(*value)
+				counts[i]++
+			}
+		}
+	}
+	for i := range means {
+		if counts[i] > 0 {
+			means[i] /= float64(counts[i])
+		}
+	}
+
+	// 共分散行列（不偏推定）+ 正則化項
+	covariance := make([][]float64, n)
+	for i := range covariance {
+		covariance[i] = make([]float64, n)
+	}
+	deviations := make([]float64, n)
+	for _, vector := range vectors {
+		for i, value := range vector {
+			deviations[i] = 0
+			if value != nil {
+				deviations[i] = float64(*value) - means[i]
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				covariance[i][j] += deviations[i] * deviations[j]
+			}
+		}
+	}
+	ridge := mahalanobisRidge * questionnaire.ScaleHalfRange() * questionnaire.ScaleHalfRange()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if len(vectors) > 1 {
+				covariance[i][j] /= float64(len(vectors) - 1)
+			} else {
+				covariance[i][j] = 0
+			}
+		}
+		covariance[i][i] += ridge
+	}
+
+	m := &mahalanobisMetric{
+		covariance: covariance,
+		inverses:   make(map[string][][]float64),
+	}
+
+	// 最大の距離は、すべての設問の差がスケールの幅となる場合の上限（sqrt(Σ|Σ⁻¹ij|) × 幅）で近似する
+	scaleWidth := float64(questionnaire.ScaleMax) - float64(questionnaire.ScaleMin)
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	var sum float64
+	for _, row := range m.inverse(all) {
+		for _, value := range row {
+			sum += math.Abs(value)
+		}
+	}
+	m.maxDistance = math.Sqrt(sum) * scaleWidth
+
+	return m
+}
+
+func (m *mahalanobisMetric) Name() string { return MetricMahalanobis }
+
+func (m *mahalanobisMetric) Distance(v1, v2 model.AnswerVector) float64 {
+	if len(v1) != len(v2) || len(v1) != len(m.covariance) {
+		return math.Inf(1)
+	}
+
+	indexes := make([]int, 0, len(v1))
+	diffs := make([]float64, 0, len(v1))
+	for i := range v1 {
+		if v1[i] == nil || v2[i] == nil {
+			continue
+		}
+		indexes = append(indexes, i)
+		diffs = append(diffs, float64(*v1[i]-*v2[i]))
+	}
+	if len(indexes) == 0 {
+		return math.Inf(1)
+	}
+
+	inverse := m.inverse(indexes)
+	var sum float64
+	for i := range diffs {
+		for j := range diffs {
+			sum += diffs[i] * inverse[i][j] * diffs[j]
+		}
+	}
+	return math.Sqrt(math.Max(0, sum) * float64(len(v1)) / float64(len(indexes)))
+}
+
+func (m *mahalanobisMetric) MaxDistance() float64 { return m.maxDistance }
+
+// inverse 指定した設問の共分散行列（部分行列）の逆行列を返す
+// 回答した設問の組み合わせごとにキャッシュする
+func (m *mahalanobisMetric) inverse(indexes []int) [][]float64 {
+	mask := make([]byte, len(m.covariance))
+	for i := range mask {
+		mask[i] = '0'
+	}
+	for _, idx := range indexes {
+		mask[idx] = '1'
+	}
+	key := string(mask)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if inverse, ok := m.inverses[key]; ok {
+		return inverse
+	}
+
+	sub := make([][]float64, len(indexes))
+	for i, row := range indexes {
+		sub[i] = make([]float64, len(indexes))
+		for j, col := range indexes {
+			sub[i][j] = m.covariance[row][col]
+		}
+	}
+	inverse := invertMatrix(sub)
+	m.inverses[key] = inverse
+	return inverse
+}
+
+// invertMatrix 部分ピボット選択付きのガウス・ジョルダン法で逆行列を計算
+// 正則化項を加えた共分散行列は正定値のため、ピボットが0になることはない
+func invertMatrix(matrix [][]float64) [][]float64 {
+	n := len(matrix)
+	a := make([][]float64, n)
+	inverse := make([][]float64, n)
+	for i := range matrix {
+		a[i] = append([]float64(nil), matrix[i]...)
+		inverse[i] = make([]float64, n)
+		inverse[i][i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+
+		scale := a[col][col]
+		for j := 0; j < n; j++ {
+			a[col][j] /= scale
+			inverse[col][j] /= scale
+		}
+		for row := 0; row < n; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			factor := a[row][col]
+			for j := 0; j < n; j++ {
+				a[row][j] -= factor * a[col][j]
+				inverse[row][j] -= factor * inverse[col][j]
+			}
+		}
+	}
+	return inverse
+}
+
+// weightAt i番目の設問の重み（weightsがnilまたは範囲外の場合は1）
+func weightAt(weights []float64, i int) float64 {
+	if i < len(weights) {
+		return weights[i]
+	}
+	return 1
+}
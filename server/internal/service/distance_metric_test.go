@@ -0,0 +1,282 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// newWeightedQuestionnaire 重み1と2の2問の質問票（-2〜2のスケール）
+func newWeightedQuestionnaire() *model.Questionnaire {
+	return newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 1), testQuestion("a", 2),
+	)
+}
+
+func TestNewDistanceMetric(t *testing.T) {
+	questionnaire := newWeightedQuestionnaire()
+
+	for _, name := range append([]string{""}, DistanceMetrics...) {
+		metric, err := NewDistanceMetric(name, questionnaire, nil)
+		if err != nil {
+			t.Errorf("NewDistanceMetric(%q) error: %v", name, err)
+			continue
+		}
+		want := name
+		if name == "" {
+			want = MetricEuclidean
+		}
+		if metric.Name() != want {
+			t.Errorf("NewDistanceMetric(%q).Name() = %q, want %q", name, metric.Name(), want)
+		}
+	}
+
+	if _, err := NewDistanceMetric("minkowski", questionnaire, nil); err == nil {
+		t.Error("NewDistanceMetric(\"minkowski\") should return an error")
+	}
+}
+
+func TestDistanceMetrics(t *testing.T) {
+	questionnaire := newWeightedQuestionnaire()
+	inf := math.Inf(1)
+
+	tests := []struct {
+		metric string
+		v1, v2 model.AnswerVector
+		want   float64
+	}{
+		// 重み付きユークリッド距離
+		{MetricEuclidean, testValues(1, 1), testValues(1, 1), 0},
+		{MetricEuclidean, testValues(2, 0), testValues(0, 1), math.Sqrt(1*4 + 2*1)},
+		{MetricEuclidean, testValues(-2, -2), testValues(2, 2), math.Sqrt(48)},
+		{MetricEuclidean, model.AnswerVector{testValue(2), nil}, testValues(0, 1), math.Sqrt(4 * 3)}, // 重みの比率で換算
+		{MetricEuclidean, model.AnswerVector{testValue(2), nil}, model.AnswerVector{nil, testValue(1)}, inf},
+		{MetricEuclidean, testValues(1, 1), testValues(1, 1, 1), inf},
+
+		// 重み付きマンハッタン距離
+		{MetricManhattan, testValues(2, 0), testValues(0, 1), 1*2 + 2*1},
+		{MetricManhattan, testValues(-2, -2), testValues(2, 2), 12},
+		{MetricManhattan, model.AnswerVector{testValue(2), nil}, testValues(0, 1), 2 * 3},
+		{MetricManhattan, model.AnswerVector{nil, nil}, testValues(0, 1), inf},
+
+		// 重み付きチェビシェフ距離（差に重みの平方根を掛ける）
+		{MetricChebyshev, testValues(2, 0), testValues(0, 1), 2},
+		{MetricChebyshev, testValues(0, 0), testValues(0, 2), math.Sqrt(2) * 2},
+		{MetricChebyshev, testValues(-2, -2), testValues(2, 2), math.Sqrt(2) * 4},
+		{MetricChebyshev, model.AnswerVector{testValue(2), nil}, testValues(-2, 1), 4},
+		{MetricChebyshev, testValues(1), testValues(1, 1), inf},
+
+		// コサイン距離（スケールの中央を原点とした向き）
+		{MetricCosine, testValues(1, 1), testValues(2, 2), 0},
+		{MetricCosine, testValues(1, 1), testValues(-2, -2), 2},
+		{MetricCosine, testValues(1, 0), testValues(0, 1), 1},
+		{MetricCosine, testValues(0, 0), testValues(2, 2), 1}, // 向きがない回答は直交とみなす
+		{MetricCosine, model.AnswerVector{nil, testValue(1)}, model.AnswerVector{testValue(1), nil}, inf},
+	}
+
+	for _, tt := range tests {
+		metric, err := NewDistanceMetric(tt.metric, questionnaire, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := metric.Distance(tt.v1, tt.v2)
+		if !floatEqual(got, tt.want) {
+			t.Errorf("%s.Distance(%v, %v) = %v, want %v", tt.metric, formatVector(tt.v1), formatVector(tt.v2), got, tt.want)
+		}
+		if back := metric.Distance(tt.v2, tt.v1); !floatEqual(back, got) {
+			t.Errorf("%s.Distance is not symmetric: %v vs %v", tt.metric, got, back)
+		}
+	}
+}
+
+// 取りうる最大の距離は、すべての設問でスケールの両端に分かれた場合の距離と一致する（マハラノビス距離は上限）
+func TestDistanceMetricMaxDistance(t *testing.T) {
+	questionnaire := newWeightedQuestionnaire()
+	population := []model.Answer{
+		{Values: testValues(2, 1)},
+		{Values: testValues(1, 2)},
+		{Values: testValues(-1, -2)},
+		{Values: testValues(-2, 0)},
+	}
+	low, high := testValues(-2, -2), testValues(2, 2)
+
+	tests := []struct {
+		metric string
+		want   float64
+	}{
+		{MetricEuclidean, math.Sqrt(48)},
+		{MetricManhattan, 12},
+		{MetricChebyshev, math.Sqrt(2) * 4},
+		{MetricCosine, 2},
+	}
+	for _, tt := range tests {
+		metric, _ := NewDistanceMetric(tt.metric, questionnaire, nil)
+		if !floatEqual(metric.MaxDistance(), tt.want) {
+			t.Errorf("%s.MaxDistance() = %v, want %v", tt.metric, metric.MaxDistance(), tt.want)
+		}
+		if tt.metric != MetricCosine && !floatEqual(metric.Distance(low, high), metric.MaxDistance()) {
+			t.Errorf("%s: distance between extremes %v != MaxDistance %v", tt.metric, metric.Distance(low, high), metric.MaxDistance())
+		}
+	}
+
+	mahalanobis, _ := NewDistanceMetric(MetricMahalanobis, questionnaire, population)
+	for _, pair := range [][2]model.AnswerVector{{low, high}, {testValues(-2, 2), testValues(2, -2)}} {
+		if d := mahalanobis.Distance(pair[0], pair[1]); d > mahalanobis.MaxDistance()+1e-9 {
+			t.Errorf("mahalanobis distance %v exceeds MaxDistance %v", d, mahalanobis.MaxDistance())
+		}
+	}
+}
+
+func TestMahalanobisMetric(t *testing.T) {
+	questionnaire := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 1), testQuestion("a", 1),
+	)
+	ridge := mahalanobisRidge * questionnaire.ScaleHalfRange() * questionnaire.ScaleHalfRange()
+
+	t.Run("母集団が2件未満なら正則化項のみ（重みなしのユークリッド距離に比例）", func(t *testing.T) {
+		for _, population := range [][]model.Answer{nil, {{Values: testValues(2, 2)}}} {
+			metric := newMahalanobisMetric(questionnaire, population)
+			got := metric.Distance(testValues(2, 0), testValues(0, 1))
+			want := math.Sqrt(5 / ridge)
+			if !floatEqual(got, want) {
+				t.Errorf("Distance = %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("相関の強い設問の組では相関の向きの差を小さく評価", func(t *testing.T) {
+		// 2問の回答が完全に一致する母集団（正則化しなければ共分散行列は特異）
+		population := []model.Answer{
+			{Values: testValues(-2, -2)},
+			{Values: testValues(-1, -1)},
+			{Values: testValues(1, 1)},
+			{Values: testValues(2, 2)},
+		}
+		metric := newMahalanobisMetric(questionnaire, population)
+
+		along := metric.Distance(testValues(-1, -1), testValues(1, 1))
+		against := metric.Distance(testValues(-1, 1), testValues(1, -1))
+		for _, d := range []float64{along, against, metric.MaxDistance()} {
+			if math.IsNaN(d) || math.IsInf(d, 0) {
+				t.Fatalf("distance should be finite, got %v", d)
+			}
+		}
+		if along >= against {
+			t.Errorf("distance along the correlation %v should be smaller than against it %v", along, against)
+		}
+	})
+
+	t.Run("すべて同じ値の設問があっても計算できる", func(t *testing.T) {
+		population := []model.Answer{
+			{Values: testValues(0, -2)},
+			{Values: testValues(0, 1)},
+			{Values: testValues(0, 2)},
+		}
+		metric := newMahalanobisMetric(questionnaire, population)
+		d := metric.Distance(testValues(2, 0), testValues(-2, 0))
+		if math.IsNaN(d) || math.IsInf(d, 0) || d <= 0 {
+			t.Errorf("Distance = %v, want positive finite value", d)
+		}
+	})
+
+	t.Run("スキップした設問は回答した設問の部分行列で計算して換算", func(t *testing.T) {
+		metric := newMahalanobisMetric(questionnaire, nil)
+		got := metric.Distance(model.AnswerVector{testValue(2), nil}, testValues(0, 1))
+		want := math.Sqrt(4 / ridge * 2)
+		if !floatEqual(got, want) {
+			t.Errorf("Distance = %v, want %v", got, want)
+		}
+		if d := metric.Distance(model.AnswerVector{testValue(2), nil}, model.AnswerVector{nil, testValue(1)}); !math.IsInf(d, 1) {
+			t.Errorf("Distance without common answers = %v, want +Inf", d)
+		}
+	})
+}
+
+func TestInvertMatrix(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+	}{
+		{"単位行列", [][]float64{{1, 0}, {0, 1}}},
+		{"対称正定値", [][]float64{{4, 1, 0.5}, {1, 3, 0.2}, {0.5, 0.2, 2}}},
+		{"ピボットの交換が必要", [][]float64{{0, 1}, {1, 0}}},
+		{"1×1", [][]float64{{0.4}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inverse := invertMatrix(tt.matrix)
+			n := len(tt.matrix)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					var product float64
+					for k := 0; k < n; k++ {
+						product += tt.matrix[i][k] * inverse[k][j]
+					}
+					want := 0.0
+					if i == j {
+						want = 1
+					}
+					if !floatEqual(product, want) {
+						t.Errorf("(A × A⁻¹)[%d][%d] = %v, want %v", i, j, product, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+// 類似度は距離指標ごとの取りうる最大の距離で正規化する
+func TestFindPhilosopherMatchesSimilarity(t *testing.T) {
+	questionnaire := newWeightedQuestionnaire()
+	philosophers := []model.Philosopher{
+		{ID: 3, Values: testValues(-2, -2)},
+		{ID: 1, Values: testValues(2, 2)},
+		{ID: 2, Values: testValues(2, 2)},
+		{ID: 4, Values: model.AnswerVector{nil, nil}}, // 比較できない哲学者は含めない
+	}
+	user := &model.Answer{Values: testValues(2, 2)}
+
+	for _, name := range []string{MetricEuclidean, MetricManhattan, MetricChebyshev, MetricCosine} {
+		metric, _ := NewDistanceMetric(name, questionnaire, nil)
+		matches := FindPhilosopherMatches(user, philosophers, metric, 10)
+
+		if len(matches.Matches) != 3 {
+			t.Fatalf("%s: len(Matches) = %d, want 3", name, len(matches.Matches))
+		}
+		// 同じ距離の哲学者はIDの小さい順
+		for i, wantID := range []int{1, 2, 3} {
+			if got := matches.Matches[i].Philosopher.ID; got != wantID {
+				t.Errorf("%s: Matches[%d].Philosopher.ID = %d, want %d", name, i, got, wantID)
+			}
+			if matches.Matches[i].Rank != i+1 {
+				t.Errorf("%s: Matches[%d].Rank = %d, want %d", name, i, matches.Matches[i].Rank, i+1)
+			}
+		}
+		if !floatEqual(matches.Matches[0].Similarity, 100) {
+			t.Errorf("%s: similarity of identical answers = %v, want 100", name, matches.Matches[0].Similarity)
+		}
+		if !floatEqual(matches.Matches[2].Similarity, 0) {
+			t.Errorf("%s: similarity of opposite answers = %v, want 0", name, matches.Matches[2].Similarity)
+		}
+		if matches.Margin == nil || !floatEqual(*matches.Margin, 0) {
+			t.Errorf("%s: Margin = %v, want 0", name, formatFloatPtr(matches.Margin))
+		}
+	}
+}
+
+// floatEqual 浮動小数点の誤差を許容して比較（無限大同士は等しい）
+func floatEqual(a, b float64) bool {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+// formatVector エラーメッセージ用に回答ベクトルを整形（スキップは"_"）
+func formatVector(vector model.AnswerVector) string {
+	return "[" + vectorKey(vector) + "]"
+}
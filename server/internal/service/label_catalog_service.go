@@ -0,0 +1,176 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// maxRepresentativePhilosophers ラベルごとに返す代表的な哲学者の最大数
+const maxRepresentativePhilosophers = 3
+
+// LabelPole ラベルの1文字が表す極
+type LabelPole struct {
+	AxisCode    string  `json:"axis_code"`
+	AxisName    string  `json:"axis_name"`
+	Letter      string  `json:"letter"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// LabelEntry ラベル1つ分の解説
+// 解説が登録されていないラベルは、各極の名前を並べた名前のみを持つ
+type LabelEntry struct {
+	model.LabelProfile
+	Poles                      []LabelPole         `json:"poles"`
+	RepresentativePhilosophers []model.Philosopher `json:"representative_philosophers"`
+}
+
+// LabelCatalog 質問票のラベル解説の一覧
+// 組み合わせの順序は、各軸を正の極・負の極・バランス型の順にした辞書順（例: "NVOP", "NVOM", "NVOX", ...）
+// バランス型の"X"は質問票のBalancedLetterがtrueの場合のみ含める
+type LabelCatalog struct {
+	questionnaire *model.Questionnaire
+	profiles      map[string]model.LabelProfile  // グループ + コード => 解説
+	philosophers  map[string][]model.Philosopher // グループ + コード => 代表的な哲学者
+}
+
+// NewLabelCatalog ラベル解説の一覧を作成
+// 代表的な哲学者は、哲学者の回答から計算したラベルが一致する哲学者のうち、該当する軸のスコアの絶対値の合計が大きい順に選ぶ
+func NewLabelCatalog(questionnaire *model.Questionnaire, profiles []model.LabelProfile, philosophers []model.Philosopher) *LabelCatalog {
+	catalog := &LabelCatalog{
+		questionnaire: questionnaire,
+		profiles:      make(map[string]model.LabelProfile, len(profiles)),
+		philosophers:  make(map[string][]model.Philosopher),
+	}
+	for _, profile := range profiles {
+		catalog.profiles[catalogKey(profile.Group, profile.Code)] = profile
+	}
+
+	// 哲学者ごとのラベルと典型度（該当グループの軸スコアの絶対値の合計）
+	strength := make(map[string]map[int]float64)
+	for _, philosopher := range philosophers {
+		label := CalculatePhiloLabel(&model.Answer{
+			QuestionnaireVersion: philosopher.QuestionnaireVersion,
+			Values:               philosopher.ToVector(),
+		}, questionnaire)
+
+		groupStrength := make(map[string]float64)
+		for _, axis := range label.Axes {
+			groupStrength[axis.Group] += math.Abs(axis.Score)
+		}
+		for group, code := range map[string]string{model.AxisGroupMain: label.MainLabel, model.AxisGroupSub: label.SubLabel} {
+			key := catalogKey(group, code)
+			catalog.philosophers[key] = append(catalog.philosophers[key], philosopher)
+			if strength[key] == nil {
+				strength[key] = make(map[int]float64)
+			}
+			strength[key][philosopher.ID] = groupStrength[group]
+		}
+	}
+
+	for key, list := range catalog.philosophers {
+		sort.SliceStable(list, func(i, j int) bool {
+			return strength[key][list[i].ID] > strength[key][list[j].ID]
+		})
+		if len(list) > maxRepresentativePhilosophers {
+			list = list[:maxRepresentativePhilosophers]
+		}
+		catalog.philosophers[key] = list
+	}
+
+	return catalog
+}
+
+// Entries 指定したグループのすべてのラベルの解説を返す
+// CalculatePhiloLabelが返しうるすべてのラベル（質問票のBalancedLetterがtrueならバランス型の軸を含む組み合わせも含む）
+func (c *LabelCatalog) Entries(group string) []LabelEntry {
+	axes := c.questionnaire.GroupAxes(group)
+	if len(axes) == 0 {
+		return []LabelEntry{}
+	}
+
+	codes := []string{""}
+	for _, axis := range axes {
+		letters := []string{axis.PositiveLetter, axis.NegativeLetter}
+		if c.questionnaire.BalancedLetter {
+			letters = append(letters, BalancedLetter)
+		}
+		next := make([]string, 0, len(codes)*len(letters))
+		for _, code := range codes {
+			for _, letter := range letters {
+				next = append(next, code+letter)
+			}
+		}
+		codes = next
+	}
+
+	entries := make([]LabelEntry, 0, len(codes))
+	for _, code := range codes {
+		if entry := c.Entry(group, code); entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries
+}
+
+// Entry 指定したグループのラベルの解説を返す
+// 質問票のBalancedLetterがtrueなら、バランス型の軸は"X"で指定できる（例: "SXOP"）
+// コードの長さや文字が軸の定義と一致しない場合はnilを返す
+func (c *LabelCatalog) Entry(group, code string) *LabelEntry {
+	axes := c.questionnaire.GroupAxes(group)
+	letters := strings.Split(code, "")
+	if len(axes) == 0 || len(letters) != len(axes) {
+		return nil
+	}
+
+	poles := make([]LabelPole, 0, len(axes))
+	poleNames := make([]string, 0, len(axes))
+	for i, axis := range axes {
+		pole := LabelPole{AxisCode: axis.Code, AxisName: axis.Name, Letter: letters[i]}
+		switch letters[i] {
+		case axis.PositiveLetter:
+			pole.Name, pole.Description = axis.PositiveName, axis.PositiveDescription
+		case axis.NegativeLetter:
+			pole.Name, pole.Description = axis.NegativeName, axis.NegativeDescription
+		case BalancedLetter:
+			if !c.questionnaire.BalancedLetter {
+				return nil
+			}
+			pole.Name = balancedPoleName
+		default:
+			return nil
+		}
+		poles = append(poles, pole)
+		poleNames = append(poleNames, pole.Name)
+	}
+
+	key := catalogKey(group, code)
+	profile, ok := c.profiles[key]
+	if !ok {
+		profile = model.LabelProfile{
+			QuestionnaireVersion: c.questionnaire.Version,
+			Group:                group,
+			Code:                 code,
+			Name:                 strings.Join(poleNames, " × "),
+		}
+	}
+
+	philosophers := c.philosophers[key]
+	if philosophers == nil {
+		philosophers = []model.Philosopher{}
+	}
+
+	return &LabelEntry{
+		LabelProfile:               profile,
+		Poles:                      poles,
+		RepresentativePhilosophers: philosophers,
+	}
+}
+
+// catalogKey グループとコードを組み合わせたキー（メインとサブで同じコードがありうるため）
+func catalogKey(group, code string) string {
+	return group + ":" + code
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// LabelFrequency ラベルごとの回答数
+type LabelFrequency struct {
+	Label string  `json:"label"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"` // 母集団に占める割合（%）
+}
+
+// LabelRarity 母集団の中でのラベルの珍しさ
+type LabelRarity struct {
+	LabelFrequency
+	RarityRank     *int `json:"rarity_rank"`     // 珍しさの順位（1が最も珍しい、同数は同順位）。母集団に現れないラベルはnil
+	DistinctLabels int  `json:"distinct_labels"` // 母集団に現れたラベルの種類数
+}
+
+// LabelFrequencies メインラベル・サブラベル・フルラベルそれぞれの回答数（回答数の多い順）
+type LabelFrequencies struct {
+	Total int              `json:"total"`
+	Main  []LabelFrequency `json:"main"`
+	Sub   []LabelFrequency `json:"sub"`
+	Full  []LabelFrequency `json:"full"`
+}
+
+// CalculateLabelFrequencies 母集団の各回答のラベルを計算し、ラベルごとの回答数を集計
+// answersはすべてquestionnaireに対する回答であること
+func CalculateLabelFrequencies(answers []*model.Answer, questionnaire *model.Questionnaire) LabelFrequencies {
+	mainCounts := make(map[string]int)
+	subCounts := make(map[string]int)
+	fullCounts := make(map[string]int)
+	for _, answer := range answers {
+		label := CalculatePhiloLabel(answer, questionnaire)
+		mainCounts[label.MainLabel]++
+		if label.SubLabel != "" {
+			subCounts[label.SubLabel]++
+		}
+		fullCounts[label.FullLabel]++
+	}
+
+	total := len(answers)
+	return LabelFrequencies{
+		Total: total,
+		Main:  buildLabelFrequencies(mainCounts, total),
+		Sub:   buildLabelFrequencies(subCounts, total),
+		Full:  buildLabelFrequencies(fullCounts, total),
+	}
+}
+
+// FindLabelRarity 集計結果の中での指定したラベルの珍しさを返す
+// 母集団に現れないラベル（絞り込み条件で対象の回答自体が除外された場合など）は回答数0とし、
+// 母集団の中での順位はないため珍しさの順位はnilとする（最も珍しいラベルとして扱わない）
+func FindLabelRarity(frequencies []LabelFrequency, label string) LabelRarity {
+	rarity := LabelRarity{
+		LabelFrequency: LabelFrequency{Label: label},
+		DistinctLabels: len(frequencies),
+	}
+	found := false
+	for _, f := range frequencies {
+		if f.Label == label {
+			rarity.LabelFrequency = f
+			found = true
+		}
+	}
+	if !found {
+		return rarity
+	}
+
+	// 自分より回答数の少ないラベルの数 + 1
+	rank := 1
+	for _, f := range frequencies {
+		if f.Count < rarity.Count {
+			rank++
+		}
+	}
+	rarity.RarityRank = &rank
+	return rarity
+}
+
+// buildLabelFrequencies 回答数の多い順（同数ならラベル順）に並べた集計結果を生成
+func buildLabelFrequencies(counts map[string]int, total int) []LabelFrequency {
+	result := make([]LabelFrequency, 0, len(counts))
+	for label, count := range counts {
+		share := 0.0
+		if total > 0 {
+			share = float64(count) / float64(total) * 100
+		}
+		result = append(result, LabelFrequency{Label: label, Count: count, Share: share})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Label < result[j].Label
+	})
+	return result
+}
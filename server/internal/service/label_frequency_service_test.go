@@ -0,0 +1,60 @@
+package service
+
+import "testing"
+
+func TestFindLabelRarity(t *testing.T) {
+	frequencies := []LabelFrequency{
+		{Label: "SV", Count: 3, Share: 50},
+		{Label: "NV", Count: 2, Share: 100.0 / 3},
+		{Label: "SA", Count: 1, Share: 100.0 / 6},
+	}
+	tied := []LabelFrequency{
+		{Label: "SV", Count: 2, Share: 50},
+		{Label: "NV", Count: 1, Share: 25},
+		{Label: "SA", Count: 1, Share: 25},
+	}
+
+	tests := []struct {
+		name        string
+		frequencies []LabelFrequency
+		label       string
+		wantCount   int
+		wantRank    *int
+	}{
+		{name: "最も珍しいラベル", frequencies: frequencies, label: "SA", wantCount: 1, wantRank: intPtr(1)},
+		{name: "最も多いラベル", frequencies: frequencies, label: "SV", wantCount: 3, wantRank: intPtr(3)},
+		{name: "同数は同順位", frequencies: tied, label: "NV", wantCount: 1, wantRank: intPtr(1)},
+		{name: "母集団に現れないラベルは順位なし", frequencies: frequencies, label: "NA", wantCount: 0, wantRank: nil},
+		{name: "母集団が空", frequencies: []LabelFrequency{}, label: "SV", wantCount: 0, wantRank: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rarity := FindLabelRarity(tt.frequencies, tt.label)
+
+			if rarity.Label != tt.label || rarity.Count != tt.wantCount {
+				t.Errorf("Label, Count = %q, %d, want %q, %d", rarity.Label, rarity.Count, tt.label, tt.wantCount)
+			}
+			if rarity.DistinctLabels != len(tt.frequencies) {
+				t.Errorf("DistinctLabels = %d, want %d", rarity.DistinctLabels, len(tt.frequencies))
+			}
+			switch {
+			case rarity.RarityRank == nil && tt.wantRank == nil:
+			case rarity.RarityRank == nil || tt.wantRank == nil || *rarity.RarityRank != *tt.wantRank:
+				t.Errorf("RarityRank = %v, want %v", formatIntPtr(rarity.RarityRank), formatIntPtr(tt.wantRank))
+			}
+		})
+	}
+}
+
+// intPtr 期待値のポインタ
+func intPtr(v int) *int {
+	return &v
+}
+
+func formatIntPtr(v *int) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
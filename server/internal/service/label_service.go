@@ -1,135 +1,153 @@
 package service
 
-import "github.com/HH19xx/philoCompass/internal/model"
-
-// CategoryScores カテゴリごとの合計スコア
-type CategoryScores struct {
-	Logic      int16 // Q1-Q3の合計
-	Ethics     int16 // Q4-Q6の合計
-	Aesthetics int16 // Q7-Q9の合計
-	Postmodern int16 // Q10-Q12の合計
-}
-
-// SubIndicators 横断的サブ指標の個別スコア
-type SubIndicators struct {
-	Q13 int16 // 不可知論 vs 可知論
-	Q14 int16 // 義務論 vs 帰結主義
-	Q15 int16 // 科学的 vs 人文的
-	Q16 int16 // 論理 vs 現象学
+import (
+	"math"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// BalancedLetter どちらの極とも言えない軸（バランス型）を表す文字（質問票のBalancedLetterがtrueの場合のみ使う）
+const BalancedLetter = "X"
+
+// balancedPoleName バランス型の軸の極の名前
+const balancedPoleName = "バランス型"
+
+// balancedStrength 極への強さ（%）の絶対値がこの値未満の軸はバランス型とする
+const balancedStrength = 10.0
+
+// AxisScore 軸ごとのスコアと判定された極
+type AxisScore struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Group    string  `json:"group"`
+	Score    float64 `json:"score"`
+	Strength float64 `json:"strength"`  // 極への強さ（%）。正の極寄りなら0〜100、負の極寄りなら-100〜0
+	Balanced bool    `json:"balanced"`  // 強さが小さく、どちらの極とも言えない
+	Letter   string  `json:"letter"`    // 例: "N"（質問票のBalancedLetterがtrueならバランス型の場合は"X"）
+	PoleName string  `json:"pole_name"` // 例: "大きな物語志向"
+	Answered int     `json:"answered"`  // 軸に属する設問のうち回答された数
 }
 
 // PhiloLabel MBTI風の哲学ラベル
+// 軸の定義（どの設問を集計するか、各極の文字と閾値）は質問票から取得する
 type PhiloLabel struct {
-	MainLabel string         `json:"main_label"` // 例: "SVOP"
-	SubLabel  string         `json:"sub_label"`  // 例: "LDSA"
-	FullLabel string         `json:"full_label"` // 例: "SVOP-LDSA"
-	Category  CategoryScores `json:"category_scores"`
-	SubScores SubIndicators  `json:"sub_scores"`
+	MainLabel string             `json:"main_label"`      // 例: "SVOP"
+	SubLabel  string             `json:"sub_label"`       // 例: "LDSA"
+	FullLabel string             `json:"full_label"`      // 例: "SVOP-LDSA"
+	Category  map[string]float64 `json:"category_scores"` // メイン軸のスコア（軸コード => スコア）
+	SubScores map[string]float64 `json:"sub_scores"`      // サブ軸のスコア（軸コード => スコア）
+	Axes      []AxisScore        `json:"axes"`
+	Skipped   int                `json:"skipped_count"` // スキップされた設問の数
 }
 
 // CalculatePhiloLabel 回答から哲学ラベルを計算
-func CalculatePhiloLabel(answer *model.Answer) PhiloLabel {
-	// カテゴリスコアを計算
-	categoryScores := CategoryScores{
-		Logic:      answer.Answer01 + answer.Answer02 + answer.Answer03,
-		Ethics:     answer.Answer04 + answer.Answer05 + answer.Answer06,
-		Aesthetics: answer.Answer07 + answer.Answer08 + answer.Answer09,
-		Postmodern: answer.Answer10 + answer.Answer11 + answer.Answer12,
+func CalculatePhiloLabel(answer *model.Answer, questionnaire *model.Questionnaire) PhiloLabel {
+	vector := answer.ToVector()
+
+	label := PhiloLabel{
+		Category:  make(map[string]float64),
+		SubScores: make(map[string]float64),
+		Axes:      make([]AxisScore, 0, len(questionnaire.Axes)),
+		Skipped:   vector.SkippedCount(),
 	}
 
-	// サブ指標スコア
-	subScores := SubIndicators{
-		Q13: answer.Answer13,
-		Q14: answer.Answer14,
-		Q15: answer.Answer15,
-		Q16: answer.Answer16,
+	// 軸ごとにスコアを計算し、ラベルの文字を決定
+	for _, axis := range questionnaire.Axes {
+		score, answered := CalculateAxisScore(vector, questionnaire, axis.Code)
+		maxScore := AxisMaxScore(questionnaire, axis.Code)
+		strength := axisStrength(axis, score, maxScore)
+		letter, poleName := judgeAxis(questionnaire, axis, score, maxScore)
+
+		switch axis.Group {
+		case model.AxisGroupMain:
+			label.MainLabel += letter
+			label.Category[axis.Code] = score
+		case model.AxisGroupSub:
+			label.SubLabel += letter
+			label.SubScores[axis.Code] = score
+		}
+
+		label.Axes = append(label.Axes, AxisScore{
+			Code:     axis.Code,
+			Name:     axis.Name,
+			Group:    axis.Group,
+			Score:    score,
+			Strength: strength,
+			Balanced: isBalanced(strength),
+			Letter:   letter,
+			PoleName: poleName,
+			Answered: answered,
+		})
 	}
 
-	// メインラベルを生成（4文字）
-	mainLabel := ""
-	mainLabel += getLogicLabel(categoryScores.Logic)
-	mainLabel += getEthicsLabel(categoryScores.Ethics)
-	mainLabel += getAestheticsLabel(categoryScores.Aesthetics)
-	mainLabel += getPostmodernLabel(categoryScores.Postmodern)
-
-	// サブラベルを生成（4文字）Q13-Q16の順序で
-	subLabel := ""
-	subLabel += getQ13Label(subScores.Q13) // 不可知論 vs 可知論
-	subLabel += getQ14Label(subScores.Q14) // 義務論 vs 帰結主義
-	subLabel += getQ15Label(subScores.Q15) // 科学的 vs 人文的
-	subLabel += getQ16Label(subScores.Q16) // 論理 vs 現象学
-
-	fullLabel := mainLabel + "-" + subLabel
-
-	return PhiloLabel{
-		MainLabel: mainLabel,
-		SubLabel:  subLabel,
-		FullLabel: fullLabel,
-		Category:  categoryScores,
-		SubScores: subScores,
+	label.FullLabel = label.MainLabel
+	if label.SubLabel != "" {
+		label.FullLabel += "-" + label.SubLabel
 	}
-}
 
-// 論理カテゴリのラベル判定
-func getLogicLabel(score int16) string {
-	if score >= 0 {
-		return "N" // Narrative（大きな物語志向）
-	}
-	return "S" // Structure（構造志向）
+	return label
 }
 
-// 倫理カテゴリのラベル判定
-func getEthicsLabel(score int16) string {
-	if score >= 0 {
-		return "V" // Virtue（徳論志向）
+// CalculateAxisScore 軸に属する設問の得点の合計と、回答された設問数を計算
+// 各設問の得点はスケールの中央を0とし、重みと逆転項目の符号反転を適用する
+// スキップされた設問がある場合は、回答済みの設問の重みの比率で全設問分に換算する
+// （全問回答した場合と同じ尺度で閾値判定や分布の集計ができるようにするため）
+// 軸の設問が1つも回答されていない場合はスコア0、回答数0を返す
+func CalculateAxisScore(vector model.AnswerVector, questionnaire *model.Questionnaire, axisCode string) (float64, int) {
+	var sum, answeredWeight, totalWeight float64
+	answered := 0
+	for _, idx := range questionnaire.AxisQuestionIndexes(axisCode) {
+		weight := questionnaire.Questions[idx].Weight
+		totalWeight += weight
+		if !vector.IsAnswered(idx) {
+			continue
+		}
+		sum += questionnaire.ScoredValue(idx, *vector[idx])
+		answeredWeight += weight
+		answered++
 	}
-	return "A" // Act（行為論志向）
-}
-
-// 美学カテゴリのラベル判定
-func getAestheticsLabel(score int16) string {
-	if score >= 0 {
-		return "O" // Ontology（存在論志向）
+	if answered == 0 || answeredWeight == 0 {
+		return 0, answered
 	}
-	return "E" // Epistemology（認識論志向）
+	return sum * totalWeight / answeredWeight, answered
 }
 
-// ポストモダンカテゴリのラベル判定
-func getPostmodernLabel(score int16) string {
-	if score >= 0 {
-		return "P" // Postmodern（ポストモダン志向）
+// AxisMaxScore 軸スコアの最大絶対値（設問の重みの合計 × スケールの中央から端までの幅）
+func AxisMaxScore(questionnaire *model.Questionnaire, axisCode string) float64 {
+	var totalWeight float64
+	for _, idx := range questionnaire.AxisQuestionIndexes(axisCode) {
+		totalWeight += questionnaire.Questions[idx].Weight
 	}
-	return "M" // Modern（モダン志向）
+	return totalWeight * questionnaire.ScaleHalfRange()
 }
 
-// Q16のラベル判定（直観と論理なら、論理のほうを信じる）
-func getQ16Label(score int16) string {
-	if score >= 0 {
-		return "L" // Logic（分析哲学的）
+// axisStrength 閾値からどれだけ極に寄っているかを、閾値から極の端までの幅に対する割合（%）で返す
+// 正の極寄りなら正、負の極寄りなら負の値になる
+func axisStrength(axis model.Axis, score, maxScore float64) float64 {
+	diff := score - axis.Threshold
+	span := maxScore - axis.Threshold
+	if diff < 0 {
+		span = maxScore + axis.Threshold
 	}
-	return "P" // Phenomenology（現象学的）
-}
-
-// Q14のラベル判定（基本的に嘘をつくことは悪いことだ）
-func getQ14Label(score int16) string {
-	if score >= 0 {
-		return "D" // Deontology（義務論的）
+	if span <= 0 {
+		return 0
 	}
-	return "C" // Consequentialism（帰結主義的）
+	return math.Max(-100, math.Min(100, diff/span*100))
 }
 
-// Q15のラベル判定（自然科学や社会科学はいずれ多くの哲学的問題を解決するだろう）
-func getQ15Label(score int16) string {
-	if score >= 0 {
-		return "S" // Scientific（科学的）
-	}
-	return "H" // Humanistic（人文的）
+// isBalanced 極への強さ（%）がバランス型の範囲にあるか
+func isBalanced(strength float64) bool {
+	return math.Abs(strength) < balancedStrength
 }
 
-// Q13のラベル判定（人間に絶対に知りえないことはある）
-func getQ13Label(score int16) string {
-	if score >= 0 {
-		return "A" // Agnostic（不可知論的）
+// judgeAxis 軸の文字と極の名前を返す
+// 質問票のBalancedLetterがtrueで極への強さが小さい場合はバランス型、それ以外はスコアが閾値以上なら正の極、未満なら負の極
+func judgeAxis(questionnaire *model.Questionnaire, axis model.Axis, score, maxScore float64) (string, string) {
+	if questionnaire.BalancedLetter && isBalanced(axisStrength(axis, score, maxScore)) {
+		return BalancedLetter, balancedPoleName
+	}
+	if score >= axis.Threshold {
+		return axis.PositiveLetter, axis.PositiveName
 	}
-	return "K" // Knowable（可知論的）
+	return axis.NegativeLetter, axis.NegativeName
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+func TestCalculatePhiloLabelBalancedAxis(t *testing.T) {
+	tests := []struct {
+		name           string
+		balancedLetter bool
+		vector         model.AnswerVector
+		wantLetter     string
+		wantPoleName   string
+		wantStrength   float64
+		wantBalanced   bool
+	}{
+		{
+			name:           "強さが10%ちょうどなら極の文字",
+			balancedLetter: true,
+			vector:         testValues(1, 0, 0, -1, 1),
+			wantLetter:     "S",
+			wantPoleName:   "positive",
+			wantStrength:   10,
+			wantBalanced:   false,
+		},
+		{
+			name:           "強さが10%未満ならバランス型",
+			balancedLetter: true,
+			vector:         testValues(1, 0, 0, -1, 0),
+			wantLetter:     BalancedLetter,
+			wantPoleName:   balancedPoleName,
+			wantBalanced:   true,
+		},
+		{
+			name:           "\"X\"を使わない質問票では閾値で極の文字を決める",
+			balancedLetter: false,
+			vector:         testValues(1, 0, 0, -1, 0),
+			wantLetter:     "S",
+			wantPoleName:   "positive",
+			wantBalanced:   true,
+		},
+		{
+			name:           "\"X\"を使わない質問票で負の極寄り",
+			balancedLetter: false,
+			vector:         testValues(-1, 0, 0, 0, 0),
+			wantLetter:     "N",
+			wantPoleName:   "negative",
+			wantStrength:   -10,
+			wantBalanced:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questionnaire := newTestQuestionnaire(
+				[]model.Axis{testAxis("a", 0)},
+				testQuestion("a", 1), testQuestion("a", 1), testQuestion("a", 1), testQuestion("a", 1), testQuestion("a", 1),
+			)
+			questionnaire.BalancedLetter = tt.balancedLetter
+
+			label := CalculatePhiloLabel(&model.Answer{Values: tt.vector}, questionnaire)
+			axis := label.Axes[0]
+
+			if label.MainLabel != axis.Letter {
+				t.Errorf("MainLabel = %q, want %q", label.MainLabel, axis.Letter)
+			}
+			if axis.Letter != tt.wantLetter || axis.PoleName != tt.wantPoleName {
+				t.Errorf("Letter, PoleName = %q, %q, want %q, %q", axis.Letter, axis.PoleName, tt.wantLetter, tt.wantPoleName)
+			}
+			assertFloatPtr(t, "Strength", &axis.Strength, &tt.wantStrength)
+			if axis.Balanced != tt.wantBalanced {
+				t.Errorf("Balanced = %v, want %v", axis.Balanced, tt.wantBalanced)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// maxExplanationQuestions 一致・不一致の設問としてそれぞれ返す最大数
+const maxExplanationQuestions = 3
+
+// QuestionAgreement 設問ごとのユーザーと哲学者の回答の一致度
+type QuestionAgreement struct {
+	Position    int     `json:"position"` // 設問の位置（1始まり）
+	Text        string  `json:"text"`
+	AxisCode    *string `json:"axis_code,omitempty"`
+	User        int16   `json:"user"`
+	Philosopher int16   `json:"philosopher"`
+	Agreement   float64 `json:"agreement"` // 0〜100%（同じ値で100%、スケールの両端に分かれた場合0%）
+}
+
+// AxisAgreement 軸ごとのユーザーと哲学者のスコアの一致度
+type AxisAgreement struct {
+	Code              string  `json:"code"`
+	Name              string  `json:"name"`
+	Group             string  `json:"group"`
+	UserScore         float64 `json:"user_score"`
+	PhilosopherScore  float64 `json:"philosopher_score"`
+	UserLetter        string  `json:"user_letter"`
+	PhilosopherLetter string  `json:"philosopher_letter"`
+	SameLetter        bool    `json:"same_letter"`
+	Agreement         float64 `json:"agreement"` // 0〜100%（同じスコアで100%、軸の両端に分かれた場合0%）
+}
+
+// MatchExplanation ユーザーと哲学者の意見が一致する点・分かれる点
+type MatchExplanation struct {
+	Agreements    []QuestionAgreement `json:"agreements"`    // 最も一致した設問から順に
+	Disagreements []QuestionAgreement `json:"disagreements"` // 最も意見が分かれた設問から順に
+	Axes          []AxisAgreement     `json:"axes"`
+}
+
+// ExplainPhilosopherMatch 2つの回答ベクトルから、一致した設問・意見が分かれた設問と軸ごとの一致度を計算
+// 設問はどちらかがスキップしたものを除き、重み付きの差（距離への寄与）の小さい順・大きい順に選ぶ
+// 差が同じ一致した設問は、両者の意見が強い（中央から離れている）設問を優先する（ともに「どちらとも言えない」より印象的なため）
+// 差が0の設問は意見が分かれた設問には含めず、同じ設問が両方に含まれることはない
+// 軸はどちらかが1問も回答していないものを除く
+func ExplainPhilosopherMatch(userVector, philosopherVector model.AnswerVector, questionnaire *model.Questionnaire) MatchExplanation {
+	scaleWidth := float64(questionnaire.ScaleMax) - float64(questionnaire.ScaleMin)
+	midpoint := questionnaire.ScaleMidpoint()
+
+	type candidate struct {
+		agreement QuestionAgreement
+		gap       float64 // 重み付きの差
+		intensity float64 // 両者の中央からの離れ具合
+	}
+	candidates := make([]candidate, 0, len(questionnaire.Questions))
+	for i, question := range questionnaire.Questions {
+		if !userVector.IsAnswered(i) || !philosopherVector.IsAnswered(i) {
+			continue
+		}
+		user, philosopher := *userVector[i], *philosopherVector[i]
+		diff := math.Abs(float64(user - philosopher))
+
+		agreement := 100.0
+		if scaleWidth > 0 {
+			agreement = (1 - diff/scaleWidth) * 100
+		}
+		candidates = append(candidates, candidate{
+			agreement: QuestionAgreement{
+				Position:    question.Position,
+				Text:        question.Text,
+				AxisCode:    question.AxisCode,
+				User:        user,
+				Philosopher: philosopher,
+				Agreement:   agreement,
+			},
+			gap:       question.Weight * diff,
+			intensity: math.Abs(float64(user)-midpoint) + math.Abs(float64(philosopher)-midpoint),
+		})
+	}
+
+	explanation := MatchExplanation{
+		Agreements:    []QuestionAgreement{},
+		Disagreements: []QuestionAgreement{},
+	}
+
+	// 一致した設問
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].gap != candidates[j].gap {
+			return candidates[i].gap < candidates[j].gap
+		}
+		if candidates[i].intensity != candidates[j].intensity {
+			return candidates[i].intensity > candidates[j].intensity
+		}
+		return candidates[i].agreement.Position < candidates[j].agreement.Position
+	})
+	chosen := make(map[int]bool)
+	for _, c := range candidates {
+		if len(explanation.Agreements) >= maxExplanationQuestions {
+			break
+		}
+		explanation.Agreements = append(explanation.Agreements, c.agreement)
+		chosen[c.agreement.Position] = true
+	}
+
+	// 意見が分かれた設問
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].gap != candidates[j].gap {
+			return candidates[i].gap > candidates[j].gap
+		}
+		return candidates[i].agreement.Position < candidates[j].agreement.Position
+	})
+	for _, c := range candidates {
+		if len(explanation.Disagreements) >= maxExplanationQuestions || c.gap == 0 {
+			break
+		}
+		if chosen[c.agreement.Position] {
+			continue
+		}
+		explanation.Disagreements = append(explanation.Disagreements, c.agreement)
+	}
+
+	// 軸ごとの一致度（ラベルの軸は両方とも質問票の軸と同じ順序）
+	userLabel := CalculatePhiloLabel(&model.Answer{QuestionnaireVersion: questionnaire.Version, Values: userVector}, questionnaire)
+	philosopherLabel := CalculatePhiloLabel(&model.Answer{QuestionnaireVersion: questionnaire.Version, Values: philosopherVector}, questionnaire)
+	explanation.Axes = make([]AxisAgreement, 0, len(userLabel.Axes))
+	for i, userAxis := range userLabel.Axes {
+		philosopherAxis := philosopherLabel.Axes[i]
+		if userAxis.Answered == 0 || philosopherAxis.Answered == 0 {
+			continue
+		}
+
+		agreement := 100.0
+		if maxScore := AxisMaxScore(questionnaire, userAxis.Code); maxScore > 0 {
+			agreement = math.Max(0, (1-math.Abs(userAxis.Score-philosopherAxis.Score)/(2*maxScore))*100)
+		}
+		explanation.Axes = append(explanation.Axes, AxisAgreement{
+			Code:              userAxis.Code,
+			Name:              userAxis.Name,
+			Group:             userAxis.Group,
+			UserScore:         userAxis.Score,
+			PhilosopherScore:  philosopherAxis.Score,
+			UserLetter:        userAxis.Letter,
+			PhilosopherLetter: philosopherAxis.Letter,
+			SameLetter:        userAxis.Letter == philosopherAxis.Letter,
+			Agreement:         agreement,
+		})
+	}
+
+	return explanation
+}
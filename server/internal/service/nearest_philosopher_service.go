@@ -2,6 +2,7 @@ package service
 
 import (
 	"math"
+	"sort"
 
 	"github.com/HH19xx/philoCompass/internal/model"
 )
@@ -10,45 +11,206 @@ import (
 type ClosestPhilosopher struct {
 	Philosopher *model.Philosopher `json:"philosopher"`
 	Distance    float64            `json:"distance"`
+	Explanation MatchExplanation   `json:"explanation"` // 意見が一致する点・分かれる点
 }
 
-// FindClosestPhilosopher ユーザーの回答に最も近い哲学者を検索
-func FindClosestPhilosopher(userAnswer *model.Answer, philosophers []model.Philosopher) *ClosestPhilosopher {
-	if len(philosophers) == 0 {
+// PhilosopherMatch 近い哲学者の順位と類似度
+type PhilosopherMatch struct {
+	Rank        int                `json:"rank"` // 1始まり
+	Philosopher *model.Philosopher `json:"philosopher"`
+	Distance    float64            `json:"distance"`
+	Similarity  float64            `json:"similarity"` // 0〜100%（距離0で100%、取りうる最大の距離で0%）
+}
+
+// PhilosopherMatches 近い順に並べた哲学者の一覧
+type PhilosopherMatches struct {
+	Metric  string             `json:"metric"` // 距離指標の名前
+	Matches []PhilosopherMatch `json:"matches"`
+	Margin  *float64           `json:"margin"` // 1位と2位の距離の差（2人未満の場合はnil）
+}
+
+// distanceTieEpsilon この差未満の距離は同じ距離とみなす（浮動小数点の誤差対策）
+const distanceTieEpsilon = 1e-9
+
+// FindClosestPhilosopher ユーザーの回答に最も近い哲学者を検索し、近い理由の説明を添えて返す
+// 同じ距離の哲学者が複数いる場合はIDの小さい哲学者を返す
+func FindClosestPhilosopher(userAnswer *model.Answer, philosophers []model.Philosopher, questionnaire *model.Questionnaire, metric DistanceMetric) *ClosestPhilosopher {
+	matches := FindPhilosopherMatches(userAnswer, philosophers, metric, 1)
+	if len(matches.Matches) == 0 {
 		return nil
 	}
 
+	closest := matches.Matches[0].Philosopher
+	return &ClosestPhilosopher{
+		Philosopher: closest,
+		Distance:    matches.Matches[0].Distance,
+		Explanation: ExplainPhilosopherMatch(userAnswer.ToVector(), closest.ToVector(), questionnaire),
+	}
+}
+
+// PhilosophicalOpposite ユーザーと正反対の哲学者
+type PhilosophicalOpposite struct {
+	Farthest *ClosestPhilosopher `json:"farthest"` // ユーザーの回答から最も遠い哲学者
+	Antipode *ClosestPhilosopher `json:"antipode"` // 反転した回答に最も近い哲学者（距離は反転した回答からの距離）
+}
+
+// FindPhilosophicalOpposite ユーザーと正反対の哲学者を検索
+// 最も遠い哲学者と、すべての回答を反転したベクトルに最も近い哲学者（対蹠点）の2通りで探す
+// 遠さは回答の差の大きさだけで決まるため、ともに「どちらとも言えない」が多い哲学者は遠くなりにくいが、
+// 対蹠点では、ユーザーが強く賛成した設問に強く反対した哲学者が選ばれる
+// いずれも説明（意見が一致する点・分かれる点）はユーザーの実際の回答との比較
+func FindPhilosophicalOpposite(userAnswer *model.Answer, philosophers []model.Philosopher, questionnaire *model.Questionnaire, metric DistanceMetric) PhilosophicalOpposite {
 	userVector := userAnswer.ToVector()
-	var closest *model.Philosopher
-	minDistance := math.MaxFloat64
+	opposite := PhilosophicalOpposite{
+		Farthest: FindFarthestPhilosopher(userAnswer, philosophers, questionnaire, metric),
+	}
+
+	mirrored := &model.Answer{
+		QuestionnaireVersion: userAnswer.QuestionnaireVersion,
+		Values:               MirrorVector(userVector, questionnaire),
+	}
+	matches := FindPhilosopherMatches(mirrored, philosophers, metric, 1)
+	if len(matches.Matches) > 0 {
+		antipode := matches.Matches[0].Philosopher
+		opposite.Antipode = &ClosestPhilosopher{
+			Philosopher: antipode,
+			Distance:    matches.Matches[0].Distance,
+			Explanation: ExplainPhilosopherMatch(userVector, antipode.ToVector(), questionnaire),
+		}
+	}
+
+	return opposite
+}
+
+// FindFarthestPhilosopher ユーザーの回答から最も遠い哲学者を検索
+// 同じ距離の哲学者が複数いる場合はIDの小さい哲学者を返す
+// 共通して回答した設問がなく比較できない哲学者は含めない
+func FindFarthestPhilosopher(userAnswer *model.Answer, philosophers []model.Philosopher, questionnaire *model.Questionnaire, metric DistanceMetric) *ClosestPhilosopher {
+	userVector := userAnswer.ToVector()
+
+	var farthest *ClosestPhilosopher
+	for i := range philosophers {
+		distance := metric.Distance(userVector, philosophers[i].ToVector())
+		if math.IsInf(distance, 1) {
+			continue
+		}
+		if farthest != nil {
+			// より遠い哲学者、同じ距離ならIDの小さい哲学者を残す
+			diff := distance - farthest.Distance
+			if diff <= -distanceTieEpsilon || (diff < distanceTieEpsilon && philosophers[i].ID >= farthest.Philosopher.ID) {
+				continue
+			}
+		}
+		farthest = &ClosestPhilosopher{Philosopher: &philosophers[i], Distance: distance}
+	}
+
+	if farthest != nil {
+		farthest.Explanation = ExplainPhilosopherMatch(userVector, farthest.Philosopher.ToVector(), questionnaire)
+	}
+	return farthest
+}
+
+// MirrorVector すべての回答をスケールの中央で反転したベクトルを返す（-2..2のスケールなら符号の反転）
+// スキップした設問はスキップのまま
+func MirrorVector(vector model.AnswerVector, questionnaire *model.Questionnaire) model.AnswerVector {
+	mirrored := make(model.AnswerVector, len(vector))
+	for i, value := range vector {
+		if value != nil {
+			v := questionnaire.ScaleMin + questionnaire.ScaleMax - *value
+			mirrored[i] = &v
+		}
+	}
+	return mirrored
+}
+
+// FindPhilosopherMatches ユーザーの回答に近い順に最大k人の哲学者を返す
+// 同じ距離の哲学者はIDの小さい順に並べる（DBからの取得順に依存しない）
+// 共通して回答した設問がなく比較できない哲学者は含めない
+// 類似度は距離指標ごとの取りうる最大の距離で正規化する
+func FindPhilosopherMatches(userAnswer *model.Answer, philosophers []model.Philosopher, metric DistanceMetric, k int) PhilosopherMatches {
+	userVector := userAnswer.ToVector()
+	maxDistance := metric.MaxDistance()
 
 	// 全哲学者との距離を計算
+	candidates := make([]PhilosopherMatch, 0, len(philosophers))
 	for i := range philosophers {
-		philoVector := philosophers[i].ToVector()
-		distance := CalculateEuclideanDistance(userVector, philoVector)
+		distance := metric.Distance(userVector, philosophers[i].ToVector())
+		if math.IsInf(distance, 1) {
+			continue
+		}
 
-		if distance < minDistance {
-			minDistance = distance
-			closest = &philosophers[i]
+		similarity := 100.0
+		if maxDistance > 0 {
+			similarity = math.Max(0, (1-distance/maxDistance)*100)
 		}
+		candidates = append(candidates, PhilosopherMatch{
+			Philosopher: &philosophers[i],
+			Distance:    distance,
+			Similarity:  similarity,
+		})
 	}
 
-	if closest == nil {
-		return nil
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if math.Abs(candidates[i].Distance-candidates[j].Distance) >= distanceTieEpsilon {
+			return candidates[i].Distance < candidates[j].Distance
+		}
+		return candidates[i].Philosopher.ID < candidates[j].Philosopher.ID
+	})
+
+	result := PhilosopherMatches{Metric: metric.Name()}
+	if len(candidates) >= 2 {
+		margin := candidates[1].Distance - candidates[0].Distance
+		result.Margin = &margin
 	}
 
-	return &ClosestPhilosopher{
-		Philosopher: closest,
-		Distance:    minDistance,
+	if k < len(candidates) {
+		candidates = candidates[:k]
+	}
+	for i := range candidates {
+		candidates[i].Rank = i + 1
 	}
+	result.Matches = candidates
+
+	return result
 }
 
-// CalculateEuclideanDistance 16次元ユークリッド距離を計算
-func CalculateEuclideanDistance(v1, v2 model.AnswerVector) float64 {
+// maxEuclideanDistance 質問票で取りうる最大の重み付きユークリッド距離（すべての設問で回答がスケールの両端に分かれた場合）
+func maxEuclideanDistance(questionnaire *model.Questionnaire) float64 {
+	scaleWidth := float64(questionnaire.ScaleMax) - float64(questionnaire.ScaleMin)
 	var sum float64
-	for i := 0; i < 16; i++ {
-		diff := float64(v1[i] - v2[i])
-		sum += diff * diff
+	for _, question := range questionnaire.Questions {
+		sum += question.Weight * scaleWidth * scaleWidth
 	}
 	return math.Sqrt(sum)
 }
+
+// CalculateEuclideanDistance 回答ベクトル間の重み付きユークリッド距離を計算
+// weightsがnilの場合は重みなし（すべて1）として扱う
+// 逆転項目は両者の符号が同時に反転するだけなので、差の大きさには影響しない
+// 次元数の異なるベクトル（別の質問票に対する回答）は比較できないため無限大を返す
+// どちらかがスキップした設問は除外し、両者が回答した設問の重みの比率で全設問分に換算する
+// 共通して回答した設問が1つもない場合も比較できないため無限大を返す
+func CalculateEuclideanDistance(v1, v2 model.AnswerVector, weights []float64) float64 {
+	if len(v1) != len(v2) {
+		return math.Inf(1)
+	}
+
+	var sum, answeredWeight, totalWeight float64
+	for i := range v1 {
+		w := 1.0
+		if i < len(weights) {
+			w = weights[i]
+		}
+		totalWeight += w
+		if v1[i] == nil || v2[i] == nil {
+			continue
+		}
+		diff := float64(*v1[i] - *v2[i])
+		sum += w * diff * diff
+		answeredWeight += w
+	}
+	if answeredWeight == 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(sum * totalWeight / answeredWeight)
+}
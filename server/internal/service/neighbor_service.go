@@ -0,0 +1,75 @@
+package service
+
+import (
+	"math"
+	"sort"
+)
+
+// NeighborDistribution 複数半径での近傍ユーザー数を取得
+type NeighborDistribution struct {
+	Radius   float64 `json:"radius"`
+	Count    int     `json:"count"`
+	Fraction float64 `json:"fraction"` // 母集団に占める割合（0〜1）
+}
+
+// NeighborRarity 回答の珍しさ（近くにいる回答者の少なさ）
+type NeighborRarity struct {
+	Population         int      `json:"population"` // 比較した回答数（自分自身を除く）
+	K                  int      `json:"k"`
+	KthNearestDistance *float64 `json:"kth_nearest_distance"` // k番目に近い回答者までの距離（比較できる回答がk件未満の場合はnil）
+}
+
+// NeighborStats 近傍ユーザー数の分布と回答の珍しさ
+type NeighborStats struct {
+	Distribution []NeighborDistribution `json:"distribution"`
+	Rarity       NeighborRarity         `json:"rarity"`
+}
+
+// neighborDistance 同じ距離にある回答の数
+type neighborDistance struct {
+	distance float64
+	count    int
+}
+
+// summarizeNeighbors 回答までの距離の一覧から、半径ごとの近傍ユーザー数とk番目に近い回答者までの距離を計算
+// 近傍を調べる回答自身は、呼び出し側でdistancesから除いておく
+// 共通して回答した設問がなく比較できない回答（距離が無限大）は、母集団には含めるがどの半径にも入らない
+func summarizeNeighbors(distances []neighborDistance, radii []float64, k int) NeighborStats {
+	sort.Slice(distances, func(i, j int) bool {
+		return distances[i].distance < distances[j].distance
+	})
+
+	population := 0
+	for _, d := range distances {
+		population += d.count
+	}
+
+	stats := NeighborStats{
+		Distribution: make([]NeighborDistribution, len(radii)),
+		Rarity:       NeighborRarity{Population: population, K: k},
+	}
+	for i, radius := range radii {
+		stats.Distribution[i].Radius = radius
+	}
+
+	seen := 0
+	for _, d := range distances {
+		for i, radius := range radii {
+			if d.distance <= radius {
+				stats.Distribution[i].Count += d.count
+			}
+		}
+		if seen < k && seen+d.count >= k && !math.IsInf(d.distance, 1) {
+			distance := d.distance
+			stats.Rarity.KthNearestDistance = &distance
+		}
+		seen += d.count
+	}
+
+	if population > 0 {
+		for i := range stats.Distribution {
+			stats.Distribution[i].Fraction = float64(stats.Distribution[i].Count) / float64(population)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"math"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// AxisPercentile 母集団の中での軸スコアの順位
+type AxisPercentile struct {
+	Code           string   `json:"code"`
+	Name           string   `json:"name"`
+	Group          string   `json:"group"`
+	Score          float64  `json:"score"`
+	Letter         string   `json:"letter"`
+	PoleName       string   `json:"pole_name"`
+	Percentile     *float64 `json:"percentile"`      // スコアが自分より低い回答者の割合（%、同点は半分として数える）
+	PolePercentile *float64 `json:"pole_percentile"` // 自分の極への傾きが自分より弱い回答者の割合（%）
+	Population     int      `json:"population"`      // 比較した回答者の数
+}
+
+// CalculatePercentileRanks 回答の各軸スコアが、母集団のスコア分布の中で何パーセンタイルにあたるかを計算
+// distributionsはCalculateCategoryDistributionsで計算した同じ質問票の分布で、スコアは同じ整数の階級に丸めて比較する
+// 母集団が空の軸と、回答者が軸の設問をすべてスキップした軸はPercentileをnilとする
+// 例: 倫理軸のPolePercentileが82なら「回答者の82%より徳論志向が強い」
+func CalculatePercentileRanks(label PhiloLabel, distributions AllCategoryDistributions, questionnaire *model.Questionnaire) []AxisPercentile {
+	result := make([]AxisPercentile, 0, len(label.Axes))
+	for _, axis := range label.Axes {
+		rank := AxisPercentile{
+			Code:     axis.Code,
+			Name:     axis.Name,
+			Group:    axis.Group,
+			Score:    axis.Score,
+			Letter:   axis.Letter,
+			PoleName: axis.PoleName,
+		}
+
+		bin := int(math.Round(axis.Score))
+		var below, equal int
+		for _, d := range distributions[axis.Code] {
+			rank.Population += d.Count
+			switch {
+			case d.Score < bin:
+				below += d.Count
+			case d.Score == bin:
+				equal += d.Count
+			}
+		}
+
+		if rank.Population > 0 && axis.Answered > 0 {
+			percentile := (float64(below) + float64(equal)/2) / float64(rank.Population) * 100
+			polePercentile := percentile
+			if isNegativePole(questionnaire, axis) {
+				polePercentile = 100 - percentile
+			}
+			rank.Percentile = &percentile
+			rank.PolePercentile = &polePercentile
+		}
+
+		result = append(result, rank)
+	}
+	return result
+}
+
+// isNegativePole 軸スコアが負の極と判定されたかどうか
+func isNegativePole(questionnaire *model.Questionnaire, score AxisScore) bool {
+	for _, axis := range questionnaire.Axes {
+		if axis.Code == score.Code {
+			return score.Letter == axis.NegativeLetter
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+func TestCalculatePercentileRanks(t *testing.T) {
+	questionnaire := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 1), testQuestion("a", 1),
+	)
+	questionnaire.BalancedLetter = true
+
+	// 軸スコア -4, 0, 1, 2, 4 の5人（すべてスキップした回答は母集団に含めない）
+	population := []*model.Answer{
+		{Values: testValues(-2, -2)},
+		{Values: testValues(0, 0)},
+		{Values: testValues(1, 0)},
+		{Values: testValues(1, 1)},
+		{Values: testValues(2, 2)},
+		{Values: model.AnswerVector{nil, nil}},
+	}
+	distributions := CalculateCategoryDistributions(population, questionnaire)
+
+	tests := []struct {
+		name               string
+		vector             model.AnswerVector
+		distributions      AllCategoryDistributions
+		wantPercentile     *float64
+		wantPolePercentile *float64
+		wantPopulation     int
+	}{
+		{
+			name:               "正の極（同点は半分として数える）",
+			vector:             testValues(1, 1),
+			distributions:      distributions,
+			wantPercentile:     floatPtr(70),
+			wantPolePercentile: floatPtr(70),
+			wantPopulation:     5,
+		},
+		{
+			name:               "負の極は下からの順位を反転",
+			vector:             testValues(-2, -2),
+			distributions:      distributions,
+			wantPercentile:     floatPtr(10),
+			wantPolePercentile: floatPtr(90),
+			wantPopulation:     5,
+		},
+		{
+			name:               "バランス型は反転しない",
+			vector:             testValues(0, 0),
+			distributions:      distributions,
+			wantPercentile:     floatPtr(30),
+			wantPolePercentile: floatPtr(30),
+			wantPopulation:     5,
+		},
+		{
+			name:               "スキップした設問は換算したスコアで比較",
+			vector:             model.AnswerVector{testValue(-2), nil},
+			distributions:      distributions,
+			wantPercentile:     floatPtr(10),
+			wantPolePercentile: floatPtr(90),
+			wantPopulation:     5,
+		},
+		{
+			name:           "軸の設問をすべてスキップ",
+			vector:         model.AnswerVector{nil, nil},
+			distributions:  distributions,
+			wantPopulation: 5,
+		},
+		{
+			name:          "母集団が空",
+			vector:        testValues(1, 1),
+			distributions: CalculateCategoryDistributions(nil, questionnaire),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label := CalculatePhiloLabel(&model.Answer{Values: tt.vector}, questionnaire)
+			ranks := CalculatePercentileRanks(label, tt.distributions, questionnaire)
+			if len(ranks) != 1 {
+				t.Fatalf("len(ranks) = %d, want 1", len(ranks))
+			}
+			rank := ranks[0]
+
+			if rank.Population != tt.wantPopulation {
+				t.Errorf("Population = %d, want %d", rank.Population, tt.wantPopulation)
+			}
+			assertFloatPtr(t, "Percentile", rank.Percentile, tt.wantPercentile)
+			assertFloatPtr(t, "PolePercentile", rank.PolePercentile, tt.wantPolePercentile)
+		})
+	}
+}
+
+// floatPtr 期待値のポインタ
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// assertFloatPtr nilかどうかと値（誤差1e-9まで）が一致することを確認
+func assertFloatPtr(t *testing.T, field string, got, want *float64) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", field, formatFloatPtr(got), formatFloatPtr(want))
+	case math.Abs(*got-*want) > 1e-9:
+		t.Errorf("%s = %v, want %v", field, *got, *want)
+	}
+}
+
+func formatFloatPtr(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// 品質判定の基準
+const (
+	longStringRatio        = 0.6  // 同じ値の連続がこの割合の設問数以上なら不注意な回答とみなす
+	minAnsweredForVariance = 4    // すべて同じ値かどうかを判定する最小の回答数
+	minMsPerQuestion       = 1000 // 1問あたりの回答時間（総回答時間から計算）の下限（ミリ秒）
+	minMedianResponseMs    = 700  // 設問ごとの回答時間の中央値の下限（ミリ秒）
+)
+
+// AssessAnswerQuality 回答が不注意な回答（ストレートライン、極端に速い回答など）でないか判定
+// いずれかの基準に該当した場合はFlaggedをtrueにし、該当した理由をすべて返す
+func AssessAnswerQuality(answer *model.Answer, questionnaire *model.Questionnaire) model.AnswerQuality {
+	vector := answer.ToVector()
+	quality := model.AnswerQuality{Reasons: model.QualityReasons{}}
+
+	// 同じ値の回答の最長の連続（スキップで途切れる）
+	if longestRun(vector) >= int(math.Ceil(float64(len(questionnaire.Questions))*longStringRatio)) {
+		quality.Reasons = append(quality.Reasons, model.QualityReasonLongString)
+	}
+
+	// すべての回答が同じ値
+	answered := len(vector) - vector.SkippedCount()
+	if answered >= minAnsweredForVariance && distinctValues(vector) == 1 {
+		quality.Reasons = append(quality.Reasons, model.QualityReasonZeroVariance)
+	}
+
+	// 回答時間が極端に短い（総回答時間を優先し、なければ設問ごとの回答時間の中央値で判定）
+	if isTooFast(answer, answered) {
+		quality.Reasons = append(quality.Reasons, model.QualityReasonTooFast)
+	}
+
+	quality.Flagged = len(quality.Reasons) > 0
+	return quality
+}
+
+// longestRun 連続する設問で同じ値を回答した最長の長さ
+func longestRun(vector model.AnswerVector) int {
+	longest, run := 0, 0
+	for i, value := range vector {
+		switch {
+		case value == nil:
+			run = 0
+		case i > 0 && vector[i-1] != nil && *vector[i-1] == *value:
+			run++
+		default:
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// distinctValues 回答された値の種類の数（スキップは除く）
+func distinctValues(vector model.AnswerVector) int {
+	values := make(map[int16]bool)
+	for _, value := range vector {
+		if value != nil {
+			values[*value] = true
+		}
+	}
+	return len(values)
+}
+
+// isTooFast 設問を読めないほど短い時間で回答しているかどうか
+// 回答時間の記録がない場合はfalse
+func isTooFast(answer *model.Answer, answered int) bool {
+	if answer.DurationMs != nil {
+		return *answer.DurationMs < answered*minMsPerQuestion
+	}
+
+	times := make([]int, 0, len(answer.ResponseTimesMs))
+	for _, ms := range answer.ResponseTimesMs {
+		if ms != nil {
+			times = append(times, *ms)
+		}
+	}
+	if len(times) == 0 {
+		return false
+	}
+
+	sort.Ints(times)
+	median := float64(times[len(times)/2])
+	if len(times)%2 == 0 {
+		median = float64(times[len(times)/2-1]+times[len(times)/2]) / 2
+	}
+	return median < minMedianResponseMs
+}
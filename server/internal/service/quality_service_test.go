@@ -0,0 +1,133 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// testValues スキップのない回答ベクトル
+func testValues(values ...int16) model.AnswerVector {
+	vector := make(model.AnswerVector, len(values))
+	for i, v := range values {
+		vector[i] = testValue(v)
+	}
+	return vector
+}
+
+// testMs 回答時間（ミリ秒）のポインタ
+func testMs(ms int) *int {
+	return &ms
+}
+
+func TestAssessAnswerQuality(t *testing.T) {
+	questions := make([]model.Question, 10)
+	for i := range questions {
+		questions[i] = testQuestion("a", 1)
+	}
+	questionnaire := newTestQuestionnaire([]model.Axis{testAxis("a", 0)}, questions...)
+
+	varied := testValues(2, -1, 0, 1, -2, 2, -1, 0, 1, -2)
+
+	tests := []struct {
+		name        string
+		vector      model.AnswerVector
+		metadata    model.AnswerMetadata
+		wantReasons model.QualityReasons
+	}{
+		{
+			name:        "ばらついた回答",
+			vector:      varied,
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name:        "同じ値が設問数の6割以上連続",
+			vector:      testValues(1, 1, 1, 1, 1, 1, -1, 0, 2, -2),
+			wantReasons: model.QualityReasons{model.QualityReasonLongString},
+		},
+		{
+			name:        "連続が6割未満",
+			vector:      testValues(1, 1, 1, 1, 1, 0, -1, 0, 2, -2),
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name: "スキップで連続が途切れる",
+			vector: model.AnswerVector{
+				testValue(1), testValue(1), testValue(1), nil, testValue(1),
+				testValue(1), testValue(1), testValue(0), testValue(2), testValue(-2),
+			},
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name:        "すべて同じ値",
+			vector:      testValues(0, 0, 0, 0, 0, 0, 0, 0, 0, 0),
+			wantReasons: model.QualityReasons{model.QualityReasonLongString, model.QualityReasonZeroVariance},
+		},
+		{
+			name: "スキップを除いてすべて同じ値",
+			vector: model.AnswerVector{
+				testValue(2), nil, testValue(2), nil, testValue(2), nil, testValue(2), nil, nil, nil,
+			},
+			wantReasons: model.QualityReasons{model.QualityReasonZeroVariance},
+		},
+		{
+			name:        "回答数が少なければ同じ値でも判定しない",
+			vector:      model.AnswerVector{testValue(2), nil, testValue(2), nil, testValue(2), nil, nil, nil, nil, nil},
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name:        "総回答時間が1問あたり1秒未満",
+			vector:      varied,
+			metadata:    model.AnswerMetadata{DurationMs: testMs(9999)},
+			wantReasons: model.QualityReasons{model.QualityReasonTooFast},
+		},
+		{
+			name:        "総回答時間が1問あたり1秒",
+			vector:      varied,
+			metadata:    model.AnswerMetadata{DurationMs: testMs(10000)},
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name:   "総回答時間を設問ごとの回答時間より優先",
+			vector: varied,
+			metadata: model.AnswerMetadata{
+				DurationMs:      testMs(20000),
+				ResponseTimesMs: model.ResponseTimes{testMs(100), testMs(100), testMs(100)},
+			},
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name:        "設問ごとの回答時間の中央値（偶数個は中央2つの平均）が下限未満",
+			vector:      varied,
+			metadata:    model.AnswerMetadata{ResponseTimesMs: model.ResponseTimes{testMs(900), testMs(500), nil, testMs(700), testMs(600)}},
+			wantReasons: model.QualityReasons{model.QualityReasonTooFast},
+		},
+		{
+			name:        "設問ごとの回答時間の中央値が下限",
+			vector:      varied,
+			metadata:    model.AnswerMetadata{ResponseTimesMs: model.ResponseTimes{testMs(900), testMs(500), nil, testMs(800), testMs(600)}},
+			wantReasons: model.QualityReasons{},
+		},
+		{
+			name:        "回答時間を計測できなかった",
+			vector:      varied,
+			metadata:    model.AnswerMetadata{ResponseTimesMs: model.ResponseTimes{nil, nil}},
+			wantReasons: model.QualityReasons{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer := &model.Answer{Values: tt.vector, AnswerMetadata: tt.metadata}
+			quality := AssessAnswerQuality(answer, questionnaire)
+
+			if !reflect.DeepEqual(quality.Reasons, tt.wantReasons) {
+				t.Errorf("Reasons = %v, want %v", quality.Reasons, tt.wantReasons)
+			}
+			if quality.Flagged != (len(tt.wantReasons) > 0) {
+				t.Errorf("Flagged = %v with reasons %v", quality.Flagged, quality.Reasons)
+			}
+		})
+	}
+}
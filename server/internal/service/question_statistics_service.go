@@ -0,0 +1,196 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// 相関係数の種類
+const (
+	CorrelationPearson  = "pearson"  // ピアソンの積率相関係数
+	CorrelationSpearman = "spearman" // スピアマンの順位相関係数（同順位は平均順位）
+)
+
+// QuestionValueCount 回答値ごとの回答数
+type QuestionValueCount struct {
+	Value int16 `json:"value"`
+	Count int   `json:"count"`
+}
+
+// QuestionStatistics 設問ごとの回答の分布
+type QuestionStatistics struct {
+	Position  int                  `json:"position"` // 設問の位置（1始まり）
+	Text      string               `json:"text"`
+	AxisCode  *string              `json:"axis_code,omitempty"`
+	Answered  int                  `json:"answered"`
+	Skipped   int                  `json:"skipped"`
+	Histogram []QuestionValueCount `json:"histogram"` // スケールの最小値から最大値まで（回答のない値も0件として含む）
+	Mean      *float64             `json:"mean"`      // 回答がない場合はnil
+	StdDev    *float64             `json:"std_dev"`   // 母標準偏差（回答がない場合はnil）
+}
+
+// CorrelationMatrix 設問間の相関行列
+// Values[i][j]はPositions[i]とPositions[j]の設問の相関係数で、両方に回答した回答のみで計算する
+// 両方に回答した回答が2件未満、またはどちらかの回答がすべて同じ値の場合は計算できないためnil
+type CorrelationMatrix struct {
+	Method    string       `json:"method"`
+	Positions []int        `json:"positions"`
+	Values    [][]*float64 `json:"values"`
+}
+
+// CalculateQuestionStatistics 母集団の回答から設問ごとの回答値の分布・平均・標準偏差を計算
+// answersはすべてquestionnaireに対する回答であること
+func CalculateQuestionStatistics(answers []*model.Answer, questionnaire *model.Questionnaire) []QuestionStatistics {
+	result := make([]QuestionStatistics, 0, len(questionnaire.Questions))
+	for i, question := range questionnaire.Questions {
+		stats := QuestionStatistics{
+			Position:  question.Position,
+			Text:      question.Text,
+			AxisCode:  question.AxisCode,
+			Histogram: make([]QuestionValueCount, 0, int(questionnaire.ScaleMax-questionnaire.ScaleMin)+1),
+		}
+		for value := questionnaire.ScaleMin; value <= questionnaire.ScaleMax; value++ {
+			stats.Histogram = append(stats.Histogram, QuestionValueCount{Value: value})
+		}
+
+		values := questionValues(answers, i)
+		stats.Answered = len(values)
+		stats.Skipped = len(answers) - len(values)
+		for _, value := range values {
+			if idx := int(value) - int(questionnaire.ScaleMin); idx >= 0 && idx < len(stats.Histogram) {
+				stats.Histogram[idx].Count++
+			}
+		}
+
+		if len(values) > 0 {
+			mean, variance := meanAndVariance(values)
+			stdDev := math.Sqrt(variance)
+			stats.Mean = &mean
+			stats.StdDev = &stdDev
+		}
+
+		result = append(result, stats)
+	}
+	return result
+}
+
+// CalculateCorrelationMatrix 母集団の回答から設問間の相関行列を計算
+// methodはCorrelationPearsonまたはCorrelationSpearman
+// answersはすべてquestionnaireに対する回答であること
+func CalculateCorrelationMatrix(answers []*model.Answer, questionnaire *model.Questionnaire, method string) (CorrelationMatrix, error) {
+	if method != CorrelationPearson && method != CorrelationSpearman {
+		return CorrelationMatrix{}, fmt.Errorf("method must be %s or %s", CorrelationPearson, CorrelationSpearman)
+	}
+
+	n := len(questionnaire.Questions)
+	matrix := CorrelationMatrix{
+		Method:    method,
+		Positions: make([]int, n),
+		Values:    make([][]*float64, n),
+	}
+	for i, question := range questionnaire.Questions {
+		matrix.Positions[i] = question.Position
+		matrix.Values[i] = make([]*float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			// 両方に回答した回答のみ（ペアワイズ除去）
+			var xs, ys []float64
+			for _, answer := range answers {
+				vector := answer.ToVector()
+				if vector.IsAnswered(i) && vector.IsAnswered(j) {
+					xs = append(xs, float64(*vector[i]))
+					ys = append(ys, float64(*vector[j]))
+				}
+			}
+			if method == CorrelationSpearman {
+				xs, ys = averageRanks(xs), averageRanks(ys)
+			}
+
+			if r, ok := pearsonCorrelation(xs, ys); ok {
+				matrix.Values[i][j] = &r
+				matrix.Values[j][i] = &r
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// questionValues 指定した設問（0始まり）に回答された値の一覧
+func questionValues(answers []*model.Answer, index int) []float64 {
+	values := []float64{}
+	for _, answer := range answers {
+		vector := answer.ToVector()
+		if vector.IsAnswered(index) {
+			values = append(values, float64(*vector[index]))
+		}
+	}
+	return values
+}
+
+// meanAndVariance 平均と母分散
+func meanAndVariance(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, sq / float64(len(values))
+}
+
+// pearsonCorrelation ピアソンの積率相関係数
+// 2件未満、またはどちらかの分散が0の場合は計算できないためfalseを返す
+func pearsonCorrelation(xs, ys []float64) (float64, bool) {
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return 0, false
+	}
+	meanX, varX := meanAndVariance(xs)
+	meanY, varY := meanAndVariance(ys)
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+
+	var cov float64
+	for i := range xs {
+		cov += (xs[i] - meanX) * (ys[i] - meanY)
+	}
+	cov /= float64(len(xs))
+
+	// 浮動小数点の誤差で範囲外にならないように丸める
+	return math.Max(-1, math.Min(1, cov/math.Sqrt(varX*varY))), true
+}
+
+// averageRanks 値を順位（1始まり、同じ値は平均順位）に変換
+func averageRanks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return values[order[a]] < values[order[b]]
+	})
+
+	ranks := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start
+		for end+1 < len(order) && values[order[end+1]] == values[order[start]] {
+			end++
+		}
+		// start〜endは同じ値（順位はstart+1〜end+1の平均）
+		rank := float64(start+end)/2 + 1
+		for k := start; k <= end; k++ {
+			ranks[order[k]] = rank
+		}
+		start = end + 1
+	}
+	return ranks
+}
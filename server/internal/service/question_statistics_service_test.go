@@ -0,0 +1,198 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// newQuestionStatisticsPopulation 3問の質問票と回答の母集団
+// 1問目と2問目は単調に増加する（ピアソンでは1未満、スピアマンでは1）、3問目はすべて同じ値
+func newQuestionStatisticsPopulation() (*model.Questionnaire, []*model.Answer) {
+	questionnaire := newTestQuestionnaire(
+		[]model.Axis{testAxis("a", 0)},
+		testQuestion("a", 1), testQuestion("a", 1), testQuestion("a", 1),
+	)
+	answers := []*model.Answer{
+		{Values: testValues(-2, -2, 0)},
+		{Values: testValues(-1, 0, 0)},
+		{Values: testValues(0, 1, 0)},
+		{Values: testValues(2, 2, 0)},
+		{Values: model.AnswerVector{nil, testValue(2), testValue(0)}}, // 1問目と組にならない
+	}
+	return questionnaire, answers
+}
+
+func TestCalculateQuestionStatistics(t *testing.T) {
+	questionnaire, answers := newQuestionStatisticsPopulation()
+	answers = append(answers, &model.Answer{Values: model.AnswerVector{testValue(2), nil, nil}})
+	// 誰も回答していない設問
+	empty := newTestQuestionnaire(nil, testQuestion("a", 1))
+
+	stats := CalculateQuestionStatistics(answers, questionnaire)
+	if len(stats) != 3 {
+		t.Fatalf("len(stats) = %d, want 3", len(stats))
+	}
+
+	tests := []struct {
+		name         string
+		got          QuestionStatistics
+		wantAnswered int
+		wantSkipped  int
+		wantCounts   []int // -2〜2の回答数
+		wantMean     *float64
+		wantStdDev   *float64
+	}{
+		{
+			name:         "1問目",
+			got:          stats[0],
+			wantAnswered: 5,
+			wantSkipped:  1,
+			wantCounts:   []int{1, 1, 1, 0, 2},
+			wantMean:     floatPtr(0.2),
+			wantStdDev:   floatPtr(math.Sqrt(12.8 / 5)),
+		},
+		{
+			name:         "すべて同じ値なら標準偏差は0",
+			got:          stats[2],
+			wantAnswered: 5,
+			wantSkipped:  1,
+			wantCounts:   []int{0, 0, 5, 0, 0},
+			wantMean:     floatPtr(0),
+			wantStdDev:   floatPtr(0),
+		},
+		{
+			name:        "回答がなければ平均と標準偏差はnil",
+			got:         CalculateQuestionStatistics([]*model.Answer{{Values: model.AnswerVector{nil}}}, empty)[0],
+			wantSkipped: 1,
+			wantCounts:  []int{0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Answered != tt.wantAnswered || tt.got.Skipped != tt.wantSkipped {
+				t.Errorf("Answered, Skipped = %d, %d, want %d, %d", tt.got.Answered, tt.got.Skipped, tt.wantAnswered, tt.wantSkipped)
+			}
+
+			counts := make([]int, 0, len(tt.got.Histogram))
+			for i, bin := range tt.got.Histogram {
+				if want := int16(i) + questionnaire.ScaleMin; bin.Value != want {
+					t.Errorf("Histogram[%d].Value = %d, want %d", i, bin.Value, want)
+				}
+				counts = append(counts, bin.Count)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("Histogram counts = %v, want %v", counts, tt.wantCounts)
+			}
+
+			assertFloatPtr(t, "Mean", tt.got.Mean, tt.wantMean)
+			assertFloatPtr(t, "StdDev", tt.got.StdDev, tt.wantStdDev)
+		})
+	}
+}
+
+func TestCalculateCorrelationMatrix(t *testing.T) {
+	questionnaire, answers := newQuestionStatisticsPopulation()
+	pearson12 := 8.25 / 8.75
+
+	tests := []struct {
+		method string
+		want   [][]*float64
+	}{
+		{
+			method: CorrelationPearson,
+			want: [][]*float64{
+				{floatPtr(1), floatPtr(pearson12), nil},
+				{floatPtr(pearson12), floatPtr(1), nil},
+				{nil, nil, nil},
+			},
+		},
+		{
+			method: CorrelationSpearman,
+			want: [][]*float64{
+				{floatPtr(1), floatPtr(1), nil},
+				{floatPtr(1), floatPtr(1), nil},
+				{nil, nil, nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			matrix, err := CalculateCorrelationMatrix(answers, questionnaire, tt.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matrix.Method != tt.method {
+				t.Errorf("Method = %q, want %q", matrix.Method, tt.method)
+			}
+			if !reflect.DeepEqual(matrix.Positions, []int{1, 2, 3}) {
+				t.Errorf("Positions = %v, want [1 2 3]", matrix.Positions)
+			}
+			for i := range tt.want {
+				for j := range tt.want[i] {
+					assertFloatPtr(t, "Values", matrix.Values[i][j], tt.want[i][j])
+				}
+			}
+		})
+	}
+
+	t.Run("同順位は平均順位", func(t *testing.T) {
+		twoQuestions := newTestQuestionnaire(nil, testQuestion("a", 1), testQuestion("a", 1))
+		tied := []*model.Answer{
+			{Values: testValues(-2, 0)},
+			{Values: testValues(-1, 0)},
+			{Values: testValues(0, 1)},
+			{Values: testValues(1, 1)},
+		}
+		matrix, err := CalculateCorrelationMatrix(tied, twoQuestions, CorrelationSpearman)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 順位 [1 2 3 4] と [1.5 1.5 3.5 3.5] のピアソンの相関係数
+		assertFloatPtr(t, "Values[0][1]", matrix.Values[0][1], floatPtr(4/math.Sqrt(20)))
+	})
+
+	t.Run("両方に回答した回答が2件未満ならnil", func(t *testing.T) {
+		twoQuestions := newTestQuestionnaire(nil, testQuestion("a", 1), testQuestion("a", 1))
+		sparse := []*model.Answer{
+			{Values: testValues(-2, 1)},
+			{Values: model.AnswerVector{testValue(1), nil}},
+			{Values: model.AnswerVector{nil, testValue(-1)}},
+		}
+		matrix, err := CalculateCorrelationMatrix(sparse, twoQuestions, CorrelationPearson)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertFloatPtr(t, "Values[0][1]", matrix.Values[0][1], nil)
+		assertFloatPtr(t, "Values[0][0]", matrix.Values[0][0], floatPtr(1))
+	})
+
+	t.Run("不明な相関係数", func(t *testing.T) {
+		if _, err := CalculateCorrelationMatrix(answers, questionnaire, "kendall"); err == nil {
+			t.Error("CalculateCorrelationMatrix with kendall should return an error")
+		}
+	})
+}
+
+func TestAverageRanks(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   []float64
+	}{
+		{[]float64{}, []float64{}},
+		{[]float64{3, 1, 2}, []float64{3, 1, 2}},
+		{[]float64{10, 20, 20, 30}, []float64{1, 2.5, 2.5, 4}},
+		{[]float64{2, 1, 2, 1, 2}, []float64{4, 1.5, 4, 1.5, 4}},
+		{[]float64{5, 5, 5}, []float64{2, 2, 2}},
+	}
+
+	for _, tt := range tests {
+		if got := averageRanks(tt.values); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("averageRanks(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HH19xx/philoCompass/internal/model"
+)
+
+// 推移を集計する期間の単位
+const (
+	TrendIntervalWeek  = "week"  // 月曜始まりの週（UTC）
+	TrendIntervalMonth = "month" // 暦月（UTC）
+)
+
+// maxTrendPeriods 推移として返す期間の最大数（範囲が広すぎる場合は新しい期間から数える）
+const maxTrendPeriods = 520
+
+// TrendPoint 1期間分の集計
+type TrendPoint struct {
+	PeriodStart time.Time          `json:"period_start"`
+	PeriodEnd   time.Time          `json:"period_end"` // この日時は含まない
+	Count       int                `json:"count"`
+	AxisMeans   map[string]float64 `json:"axis_means"`   // 軸コード => 平均スコア（1問も回答されていない回答は除く）
+	LabelShares []LabelFrequency   `json:"label_shares"` // メインラベルごとの回答数と割合（回答数の多い順）
+}
+
+// CalculateTrends 回答の作成日時を期間ごとにまとめ、軸スコアの平均・メインラベルの割合・回答数の推移を計算
+// 最初の回答から最後の回答までの期間を、回答のない期間も含めて古い順に返す
+// answersはすべてquestionnaireに対する回答であること
+func CalculateTrends(answers []*model.Answer, questionnaire *model.Questionnaire, interval string) ([]TrendPoint, error) {
+	if interval != TrendIntervalWeek && interval != TrendIntervalMonth {
+		return nil, fmt.Errorf("interval must be %s or %s", TrendIntervalWeek, TrendIntervalMonth)
+	}
+	if len(answers) == 0 {
+		return []TrendPoint{}, nil
+	}
+
+	// 期間の開始日時 => 期間内の回答
+	first, last := answers[0].CreatedAt, answers[0].CreatedAt
+	periods := make(map[time.Time][]*model.Answer)
+	for _, answer := range answers {
+		if answer.CreatedAt.Before(first) {
+			first = answer.CreatedAt
+		}
+		if answer.CreatedAt.After(last) {
+			last = answer.CreatedAt
+		}
+		start := trendPeriodStart(answer.CreatedAt, interval)
+		periods[start] = append(periods[start], answer)
+	}
+
+	start := trendPeriodStart(first, interval)
+	lastStart := trendPeriodStart(last, interval)
+	starts := []time.Time{}
+	for t := start; !t.After(lastStart); t = nextTrendPeriod(t, interval) {
+		starts = append(starts, t)
+	}
+	if len(starts) > maxTrendPeriods {
+		starts = starts[len(starts)-maxTrendPeriods:]
+	}
+
+	points := make([]TrendPoint, 0, len(starts))
+	for _, periodStart := range starts {
+		periodAnswers := periods[periodStart]
+		point := TrendPoint{
+			PeriodStart: periodStart,
+			PeriodEnd:   nextTrendPeriod(periodStart, interval),
+			Count:       len(periodAnswers),
+			AxisMeans:   make(map[string]float64),
+			LabelShares: CalculateLabelFrequencies(periodAnswers, questionnaire).Main,
+		}
+
+		sums := make(map[string]float64)
+		counts := make(map[string]int)
+		for _, answer := range periodAnswers {
+			for _, axis := range CalculatePhiloLabel(answer, questionnaire).Axes {
+				if axis.Answered == 0 {
+					continue
+				}
+				sums[axis.Code] += axis.Score
+				counts[axis.Code]++
+			}
+		}
+		for code, sum := range sums {
+			point.AxisMeans[code] = sum / float64(counts[code])
+		}
+
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// trendPeriodStart 日時が含まれる期間の開始日時（UTC）
+func trendPeriodStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	if interval == TrendIntervalMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // 月曜日からの日数
+	return day.AddDate(0, 0, -offset)
+}
+
+// nextTrendPeriod 次の期間の開始日時
+func nextTrendPeriod(start time.Time, interval string) time.Time {
+	if interval == TrendIntervalMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
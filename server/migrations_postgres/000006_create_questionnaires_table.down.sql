@@ -0,0 +1,4 @@
+-- 質問票関連テーブルを削除
+DROP TABLE IF EXISTS questionnaire_scale_labels;
+DROP TABLE IF EXISTS questionnaire_questions;
+DROP TABLE IF EXISTS questionnaires;
@@ -0,0 +1,47 @@
+-- 質問票テーブル
+-- 質問文・カテゴリ・選択肢ラベルをバージョン単位で管理する
+CREATE TABLE IF NOT EXISTS questionnaires (
+    version         INTEGER PRIMARY KEY,                      -- 質問票のバージョン（1始まり）
+    title           VARCHAR(100) NOT NULL,
+    description     TEXT,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by      VARCHAR(50),
+    updated_at      TIMESTAMP,
+    updated_by      VARCHAR(50)
+);
+
+-- 質問テーブル
+-- positionは回答ベクトル上の位置（1始まり）
+CREATE TABLE IF NOT EXISTS questionnaire_questions (
+    id                      SERIAL PRIMARY KEY,
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    position                SMALLINT NOT NULL,
+    category                VARCHAR(50) NOT NULL,
+    text                    TEXT NOT NULL,
+    UNIQUE (questionnaire_version, position)
+);
+
+-- 選択肢ラベルテーブル（例: 2 => "まさにその通りだ"）
+CREATE TABLE IF NOT EXISTS questionnaire_scale_labels (
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    value                   SMALLINT NOT NULL,
+    label                   VARCHAR(100) NOT NULL,
+    PRIMARY KEY (questionnaire_version, value)
+);
+
+-- RLS有効化（質問票は公開情報のため全員が閲覧可能）
+ALTER TABLE questionnaires ENABLE ROW LEVEL SECURITY;
+ALTER TABLE questionnaire_questions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE questionnaire_scale_labels ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "questionnaires_read_all" ON questionnaires
+    FOR SELECT
+    USING (true);
+
+CREATE POLICY "questionnaire_questions_read_all" ON questionnaire_questions
+    FOR SELECT
+    USING (true);
+
+CREATE POLICY "questionnaire_scale_labels_read_all" ON questionnaire_scale_labels
+    FOR SELECT
+    USING (true);
@@ -0,0 +1,39 @@
+-- 固定カラム形式に戻す（ロールバック時）
+-- 注意: 16問以外の質問票に対する回答が存在する場合は失敗する
+ALTER TABLE answers
+    ADD COLUMN answer_01 SMALLINT,
+    ADD COLUMN answer_02 SMALLINT,
+    ADD COLUMN answer_03 SMALLINT,
+    ADD COLUMN answer_04 SMALLINT,
+    ADD COLUMN answer_05 SMALLINT,
+    ADD COLUMN answer_06 SMALLINT,
+    ADD COLUMN answer_07 SMALLINT,
+    ADD COLUMN answer_08 SMALLINT,
+    ADD COLUMN answer_09 SMALLINT,
+    ADD COLUMN answer_10 SMALLINT,
+    ADD COLUMN answer_11 SMALLINT,
+    ADD COLUMN answer_12 SMALLINT,
+    ADD COLUMN answer_13 SMALLINT,
+    ADD COLUMN answer_14 SMALLINT,
+    ADD COLUMN answer_15 SMALLINT,
+    ADD COLUMN answer_16 SMALLINT;
+
+UPDATE answers SET
+    answer_01 = (answer_vector::json->>0)::smallint,
+    answer_02 = (answer_vector::json->>1)::smallint,
+    answer_03 = (answer_vector::json->>2)::smallint,
+    answer_04 = (answer_vector::json->>3)::smallint,
+    answer_05 = (answer_vector::json->>4)::smallint,
+    answer_06 = (answer_vector::json->>5)::smallint,
+    answer_07 = (answer_vector::json->>6)::smallint,
+    answer_08 = (answer_vector::json->>7)::smallint,
+    answer_09 = (answer_vector::json->>8)::smallint,
+    answer_10 = (answer_vector::json->>9)::smallint,
+    answer_11 = (answer_vector::json->>10)::smallint,
+    answer_12 = (answer_vector::json->>11)::smallint,
+    answer_13 = (answer_vector::json->>12)::smallint,
+    answer_14 = (answer_vector::json->>13)::smallint,
+    answer_15 = (answer_vector::json->>14)::smallint,
+    answer_16 = (answer_vector::json->>15)::smallint;
+
+ALTER TABLE answers DROP COLUMN IF EXISTS answer_vector;
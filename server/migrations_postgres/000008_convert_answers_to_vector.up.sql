@@ -0,0 +1,35 @@
+-- 回答を設問数に依存しない形式で保存する
+-- answer_01 ~ answer_16の固定カラムをJSON配列（例: "[1, 0, -2, ...]"）のanswer_vectorに置き換える
+-- 次元数は質問票（questionnaire_version）の設問数で決まる
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS answer_vector TEXT;
+
+-- 既存データを移行
+UPDATE answers
+SET answer_vector = json_build_array(
+    answer_01, answer_02, answer_03, answer_04,
+    answer_05, answer_06, answer_07, answer_08,
+    answer_09, answer_10, answer_11, answer_12,
+    answer_13, answer_14, answer_15, answer_16
+)::text
+WHERE answer_vector IS NULL;
+
+ALTER TABLE answers ALTER COLUMN answer_vector SET NOT NULL;
+
+-- 固定カラムを削除
+ALTER TABLE answers
+    DROP COLUMN IF EXISTS answer_01,
+    DROP COLUMN IF EXISTS answer_02,
+    DROP COLUMN IF EXISTS answer_03,
+    DROP COLUMN IF EXISTS answer_04,
+    DROP COLUMN IF EXISTS answer_05,
+    DROP COLUMN IF EXISTS answer_06,
+    DROP COLUMN IF EXISTS answer_07,
+    DROP COLUMN IF EXISTS answer_08,
+    DROP COLUMN IF EXISTS answer_09,
+    DROP COLUMN IF EXISTS answer_10,
+    DROP COLUMN IF EXISTS answer_11,
+    DROP COLUMN IF EXISTS answer_12,
+    DROP COLUMN IF EXISTS answer_13,
+    DROP COLUMN IF EXISTS answer_14,
+    DROP COLUMN IF EXISTS answer_15,
+    DROP COLUMN IF EXISTS answer_16;
@@ -0,0 +1,41 @@
+-- 固定カラム形式に戻す（ロールバック時）
+DROP INDEX IF EXISTS idx_philosophers_questionnaire_version;
+
+ALTER TABLE philosophers
+    ADD COLUMN answer_01 SMALLINT,
+    ADD COLUMN answer_02 SMALLINT,
+    ADD COLUMN answer_03 SMALLINT,
+    ADD COLUMN answer_04 SMALLINT,
+    ADD COLUMN answer_05 SMALLINT,
+    ADD COLUMN answer_06 SMALLINT,
+    ADD COLUMN answer_07 SMALLINT,
+    ADD COLUMN answer_08 SMALLINT,
+    ADD COLUMN answer_09 SMALLINT,
+    ADD COLUMN answer_10 SMALLINT,
+    ADD COLUMN answer_11 SMALLINT,
+    ADD COLUMN answer_12 SMALLINT,
+    ADD COLUMN answer_13 SMALLINT,
+    ADD COLUMN answer_14 SMALLINT,
+    ADD COLUMN answer_15 SMALLINT,
+    ADD COLUMN answer_16 SMALLINT;
+
+UPDATE philosophers SET
+    answer_01 = (answer_vector::json->>0)::smallint,
+    answer_02 = (answer_vector::json->>1)::smallint,
+    answer_03 = (answer_vector::json->>2)::smallint,
+    answer_04 = (answer_vector::json->>3)::smallint,
+    answer_05 = (answer_vector::json->>4)::smallint,
+    answer_06 = (answer_vector::json->>5)::smallint,
+    answer_07 = (answer_vector::json->>6)::smallint,
+    answer_08 = (answer_vector::json->>7)::smallint,
+    answer_09 = (answer_vector::json->>8)::smallint,
+    answer_10 = (answer_vector::json->>9)::smallint,
+    answer_11 = (answer_vector::json->>10)::smallint,
+    answer_12 = (answer_vector::json->>11)::smallint,
+    answer_13 = (answer_vector::json->>12)::smallint,
+    answer_14 = (answer_vector::json->>13)::smallint,
+    answer_15 = (answer_vector::json->>14)::smallint,
+    answer_16 = (answer_vector::json->>15)::smallint;
+
+ALTER TABLE philosophers DROP COLUMN IF EXISTS answer_vector;
+ALTER TABLE philosophers DROP COLUMN IF EXISTS questionnaire_version;
@@ -0,0 +1,37 @@
+-- 哲学者の回答も設問数に依存しない形式で保存する
+-- どのバージョンの質問票に対する回答かをquestionnaire_versionで管理する
+ALTER TABLE philosophers ADD COLUMN IF NOT EXISTS questionnaire_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE philosophers ADD COLUMN IF NOT EXISTS answer_vector TEXT;
+
+-- 既存データを移行
+UPDATE philosophers
+SET answer_vector = json_build_array(
+    answer_01, answer_02, answer_03, answer_04,
+    answer_05, answer_06, answer_07, answer_08,
+    answer_09, answer_10, answer_11, answer_12,
+    answer_13, answer_14, answer_15, answer_16
+)::text
+WHERE answer_vector IS NULL;
+
+ALTER TABLE philosophers ALTER COLUMN answer_vector SET NOT NULL;
+
+-- 固定カラムを削除
+ALTER TABLE philosophers
+    DROP COLUMN IF EXISTS answer_01,
+    DROP COLUMN IF EXISTS answer_02,
+    DROP COLUMN IF EXISTS answer_03,
+    DROP COLUMN IF EXISTS answer_04,
+    DROP COLUMN IF EXISTS answer_05,
+    DROP COLUMN IF EXISTS answer_06,
+    DROP COLUMN IF EXISTS answer_07,
+    DROP COLUMN IF EXISTS answer_08,
+    DROP COLUMN IF EXISTS answer_09,
+    DROP COLUMN IF EXISTS answer_10,
+    DROP COLUMN IF EXISTS answer_11,
+    DROP COLUMN IF EXISTS answer_12,
+    DROP COLUMN IF EXISTS answer_13,
+    DROP COLUMN IF EXISTS answer_14,
+    DROP COLUMN IF EXISTS answer_15,
+    DROP COLUMN IF EXISTS answer_16;
+
+CREATE INDEX IF NOT EXISTS idx_philosophers_questionnaire_version ON philosophers (questionnaire_version);
@@ -0,0 +1,29 @@
+-- 軸定義テーブル
+-- ラベルの各文字を決める軸（集計する設問・両極の文字と名前・閾値）を質問票ごとに管理する
+-- 軸スコアが閾値以上なら正の極、未満なら負の極の文字になる
+CREATE TABLE IF NOT EXISTS questionnaire_axes (
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    code                    VARCHAR(50) NOT NULL,                 -- 例: "logic"
+    position                SMALLINT NOT NULL,                    -- ラベル内の並び順（1始まり）
+    label_group             VARCHAR(10) NOT NULL CHECK (label_group IN ('main', 'sub')),
+    name                    VARCHAR(100) NOT NULL,                -- 例: "論理"
+    positive_letter         CHAR(1) NOT NULL,                     -- 例: "N"
+    positive_name           VARCHAR(100) NOT NULL,                -- 例: "大きな物語志向"
+    negative_letter         CHAR(1) NOT NULL,                     -- 例: "S"
+    negative_name           VARCHAR(100) NOT NULL,                -- 例: "構造志向"
+    threshold               REAL NOT NULL DEFAULT 0,
+    created_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP,
+    PRIMARY KEY (questionnaire_version, code),
+    UNIQUE (questionnaire_version, label_group, position)
+);
+
+-- 各設問がどの軸のスコアに加算されるか
+ALTER TABLE questionnaire_questions ADD COLUMN IF NOT EXISTS axis_code VARCHAR(50);
+
+-- RLS有効化（軸定義は公開情報のため全員が閲覧可能）
+ALTER TABLE questionnaire_axes ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "questionnaire_axes_read_all" ON questionnaire_axes
+    FOR SELECT
+    USING (true);
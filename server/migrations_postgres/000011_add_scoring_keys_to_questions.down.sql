@@ -0,0 +1,2 @@
+ALTER TABLE questionnaire_questions DROP COLUMN IF EXISTS reverse_keyed;
+ALTER TABLE questionnaire_questions DROP COLUMN IF EXISTS weight;
@@ -0,0 +1,5 @@
+-- 設問ごとの採点キー
+-- weight: 軸スコアと距離計算での重み
+-- reverse_keyed: 逆転項目（否定的な文言の設問）は回答値の符号を反転して軸スコアに加算する
+ALTER TABLE questionnaire_questions ADD COLUMN IF NOT EXISTS weight REAL NOT NULL DEFAULT 1 CHECK (weight > 0);
+ALTER TABLE questionnaire_questions ADD COLUMN IF NOT EXISTS reverse_keyed BOOLEAN NOT NULL DEFAULT false;
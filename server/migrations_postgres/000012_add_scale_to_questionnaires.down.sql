@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_answers_validate_scale ON answers;
+DROP FUNCTION IF EXISTS validate_answer_vector_scale();
+ALTER TABLE questionnaires DROP CONSTRAINT IF EXISTS chk_questionnaires_scale;
+ALTER TABLE questionnaires DROP COLUMN IF EXISTS scale_max;
+ALTER TABLE questionnaires DROP COLUMN IF EXISTS scale_min;
@@ -0,0 +1,28 @@
+-- 質問票ごとの回答スケール（例: 5段階なら-2 ~ 2、スライダーなら0 ~ 10）
+-- 既存の質問票は従来どおり-2 ~ 2の5段階評価
+ALTER TABLE questionnaires ADD COLUMN IF NOT EXISTS scale_min SMALLINT NOT NULL DEFAULT -2;
+ALTER TABLE questionnaires ADD COLUMN IF NOT EXISTS scale_max SMALLINT NOT NULL DEFAULT 2;
+ALTER TABLE questionnaires DROP CONSTRAINT IF EXISTS chk_questionnaires_scale;
+ALTER TABLE questionnaires ADD CONSTRAINT chk_questionnaires_scale CHECK (scale_min < scale_max);
+
+-- 回答値が質問票のスケール内にあることを検証するトリガー
+-- （回答ベクトルはJSON配列のため、CHECK制約の代わりにトリガーで検証する）
+CREATE OR REPLACE FUNCTION validate_answer_vector_scale() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM json_array_elements_text(NEW.answer_vector::json) AS v(value), questionnaires q
+        WHERE q.version = NEW.questionnaire_version
+          AND v.value IS NOT NULL
+          AND (v.value::smallint < q.scale_min OR v.value::smallint > q.scale_max)
+    ) THEN
+        RAISE EXCEPTION 'answer value out of questionnaire scale (questionnaire_version=%)', NEW.questionnaire_version;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_answers_validate_scale ON answers;
+CREATE TRIGGER trg_answers_validate_scale
+    BEFORE INSERT OR UPDATE OF answer_vector, questionnaire_version ON answers
+    FOR EACH ROW EXECUTE FUNCTION validate_answer_vector_scale();
@@ -0,0 +1,18 @@
+-- 回答の下書きテーブル
+-- 質問に1問ずつ回答しながら途中保存し、最後に確定してanswersへ移す
+-- idは推測できないランダムなトークン（匿名ユーザーはこのトークンで下書きを再開する）
+CREATE TABLE IF NOT EXISTS answer_drafts (
+    id                      VARCHAR(64) PRIMARY KEY,
+    user_id                 INTEGER REFERENCES "user"(id) ON DELETE CASCADE, -- 匿名の場合はNULL
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version),
+    answer_vector           TEXT NOT NULL,                                   -- 未回答の設問はnull
+    created_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP,
+    expires_at              TIMESTAMP NOT NULL                               -- 更新のたびに延長される
+);
+
+-- 期限切れの下書きの削除を高速化
+CREATE INDEX IF NOT EXISTS idx_answer_drafts_expires_at ON answer_drafts (expires_at);
+
+-- RLS有効化（下書きはバックエンド経由でのみ読み書きするためポリシーは作成しない）
+ALTER TABLE answer_drafts ENABLE ROW LEVEL SECURITY;
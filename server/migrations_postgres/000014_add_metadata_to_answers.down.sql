@@ -0,0 +1,4 @@
+ALTER TABLE answers DROP COLUMN IF EXISTS client_version;
+ALTER TABLE answers DROP COLUMN IF EXISTS locale;
+ALTER TABLE answers DROP COLUMN IF EXISTS duration_ms;
+ALTER TABLE answers DROP COLUMN IF EXISTS response_times_ms;
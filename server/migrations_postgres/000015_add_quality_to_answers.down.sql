@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_answers_quality_flagged;
+ALTER TABLE answers DROP COLUMN IF EXISTS quality_reasons;
+ALTER TABLE answers DROP COLUMN IF EXISTS quality_flagged;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS label_profiles;
+ALTER TABLE questionnaire_axes DROP COLUMN IF EXISTS negative_description;
+ALTER TABLE questionnaire_axes DROP COLUMN IF EXISTS positive_description;
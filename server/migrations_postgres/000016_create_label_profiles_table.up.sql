@@ -0,0 +1,29 @@
+-- 各極の説明（ラベル解説で使用）
+ALTER TABLE questionnaire_axes ADD COLUMN IF NOT EXISTS positive_description TEXT;
+ALTER TABLE questionnaire_axes ADD COLUMN IF NOT EXISTS negative_description TEXT;
+
+-- ラベルのタイプ解説テーブル
+-- メインラベル（例: "SVOP"）・サブラベル（例: "ADSL"）ごとの名前と解説を質問票ごとに管理する
+-- 解説が登録されていないラベルは、各極の名前から組み立てた名前のみを返す
+CREATE TABLE IF NOT EXISTS label_profiles (
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    label_group             VARCHAR(10) NOT NULL CHECK (label_group IN ('main', 'sub')),
+    code                    VARCHAR(20) NOT NULL,                 -- 例: "SVOP"
+    name                    VARCHAR(100) NOT NULL,                -- 例: "構造 × 徳 × 存在 × ポストモダン"
+    description             TEXT,                                 -- 傾向
+    strengths               TEXT,
+    weaknesses              TEXT,
+    relations               TEXT,                                 -- 他のタイプとの距離感
+    created_at              TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by              VARCHAR(50),
+    updated_at              TIMESTAMP,
+    updated_by              VARCHAR(50),
+    PRIMARY KEY (questionnaire_version, label_group, code)
+);
+
+-- RLS有効化（ラベル解説は公開情報のため全員が閲覧可能）
+ALTER TABLE label_profiles ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "label_profiles_read_all" ON label_profiles
+    FOR SELECT
+    USING (true);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_answers_device_created;
+ALTER TABLE answers DROP COLUMN IF EXISTS device_id;
@@ -0,0 +1,5 @@
+-- 匿名回答者の端末ID（クライアントが生成する任意の識別子）
+-- 統計の母集団を回答者ごとの最新の回答に絞る際、ログインしていない回答者の識別に使う
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS device_id VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_answers_device_created ON answers (device_id, created_at DESC);
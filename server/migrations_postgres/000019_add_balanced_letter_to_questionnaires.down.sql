@@ -0,0 +1 @@
+ALTER TABLE questionnaires DROP COLUMN IF EXISTS balanced_letter;
@@ -0,0 +1,39 @@
+-- =========================================
+-- 質問票 バージョン1のラベル解説
+-- メインラベル16タイプの解説と、各軸の極の説明
+-- =========================================
+
+BEGIN;
+
+INSERT INTO label_profiles
+(questionnaire_version, label_group, code, name, description, strengths, weaknesses, relations)
+VALUES
+  (1, 'main', 'NVOP', '物語 × 徳 × 存在 × ポストモダン', '経験・物語・倫理的成熟を重視しつつ、多元的な世界観を肯定。', '他者理解が柔らかく、多様な価値の共存に強い。', '基準が曖昧になりやすく、実践的な判断が揺れやすい。', 'SAEM（構造・行為・認識・モダン）とは価値基盤が最も遠い。'),
+  (1, 'main', 'NVOM', '物語 × 徳 × 存在 × モダン', '物語的理解を保持しながら、伝統的・普遍的秩序にも寄り添う。', '調和志向が強く、倫理観が安定。', '物語と普遍性の折り合いが難しく、中庸に見えやすい。', 'SAEP や SAOP など構造・行為系とは摩擦が出やすい。'),
+  (1, 'main', 'NVEP', '物語 × 徳 × 認識 × ポストモダン', '価値・物語・認識の諸相を相対的に捉え、柔らかい思考体系を好む。', '多角的、調停的、融和的。', '決断力に欠ける場合がある。', 'SVEM のような体系・普遍主義系とは隔たりが大きい。'),
+  (1, 'main', 'NVEM', '物語 × 徳 × 認識 × モダン', '物語的理解・徳倫理・認識論的美学を古典的枠内で整理したがる。', '道徳と認識論のつながりを直観的に扱いやすい。', '全体像の説明が抽象に寄りやすい。', 'SAOP（構造・行為・存在）とは基調が異なる。'),
+  (1, 'main', 'NAOP', '物語 × 行為 × 存在 × ポストモダン', '現実の具体的行為を物語的・存在論的に読み解く志向。', '状況理解力が高く、倫理判断が柔軟。', '基準が揺らぎやすい。', 'SVEM・SAEM など普遍主義系は対極。'),
+  (1, 'main', 'NAOM', '物語 × 行為 × 存在 × モダン', '直観・行為・存在の3点を、古典的秩序の中で統合しようとする。', '道徳判断が分かりやすく、バランスが良い。', '説明が"雰囲気的"になりやすい。', '詳細な体系化を好む SAEP・SVEP とは差が出る。'),
+  (1, 'main', 'NAEP', '物語 × 行為 × 認識 × ポストモダン', '行為と認識の結びつきを軽快に扱い、世界を相対的に見る。', '視野が広く、創造性が高い。', '判断の一貫性が弱い場合あり。', 'SAOM（構造・行為・存在・モダン）など安定重視型と噛み合いにくい。'),
+  (1, 'main', 'NAEM', '物語 × 行為 × 認識 × モダン', '認識の構造を気にしつつ、物語性と実践性を古典的秩序に置く。', '常識的な倫理観と、柔らかい認識論の両立。', '美学的・価値論的基盤が説明しづらい。', 'SAOP（構造・行為・存在）とは価値の読み方が異なる。'),
+  (1, 'main', 'SVOP', '構造 × 徳 × 存在 × ポストモダン', '体系性と存在論的視点を持ちながら、価値は多様性を認める。', '理論と経験の折衷が得意。', '説明に抽象度が出やすい。', 'NAEP・NVEP とは方向性がずれがち。'),
+  (1, 'main', 'SVOM', '構造 × 徳 × 存在 × モダン', 'いわゆる"正統派の哲学的姿勢"に近い安定型。', '体系性・道徳性・存在論の3点がきれいに並ぶ。', '柔軟さに欠ける印象を与える場合あり。', '極端なポストモダン系（NVOPなど）とは遠い。'),
+  (1, 'main', 'SVEP', '構造 × 徳 × 認識 × ポストモダン', '認識論的整理と道徳、そして多元性を組み合わせる穏当な相対主義。', '理論・倫理・価値観の折衷が得意。', '立場表明が弱く見える。', 'SAOM や SAEM のような普遍系とは距離。'),
+  (1, 'main', 'SVEM', '構造 × 徳 × 認識 × モダン', '伝統的・普遍的価値観を、認識論的精密さで支えるタイプ。', '理論的で筋が通る。', '融通が利きにくい場面がある。', 'NVOP・NAOP など物語・相対主義系とは真逆。'),
+  (1, 'main', 'SAOP', '構造 × 行為 × 存在 × ポストモダン', '行為の根拠を世界の構造で説明しつつ、多元的価値観も受容。', '実践と理論の接続がうまい。', '立場が複雑で誤解されやすい。', 'NVOM（物語・徳・古典）とは方向が異なる。'),
+  (1, 'main', 'SAOM', '構造 × 行為 × 存在 × モダン', '古典的規範と構造理解を背景に、行為を正しく位置づける。', '倫理判断が明確で、一貫性が高い。', '柔軟性が少ない印象を与えることも。', 'NAEP のような相対系とは合わない。'),
+  (1, 'main', 'SAEP', '構造 × 行為 × 認識 × ポストモダン', '行為・認識・構造を多元的に扱う、柔軟な実践派。', '状況に即した幅広い判断ができる。', '全体観がまとまりにくい。', 'NVOM（徳・物語・モダン）とは相性が悪い。'),
+  (1, 'main', 'SAEM', '構造 × 行為 × 認識 × モダン', '最も"硬派で伝統的"な構成。体系・行為・認識論が一直線に結びつく。', '安定・整合性・普遍性に強い。', '独創性や相対的視点が入りにくい。', 'NVOP（物語・徳・存在・ポストモダン）とは最大距離。')
+ON CONFLICT (questionnaire_version, label_group, code) DO NOTHING;
+
+-- 各極の説明（未設定の軸のみ）
+UPDATE questionnaire_axes SET positive_description = '世界や歴史を一貫した大きな物語・全体像として理解しようとする', negative_description = '物語よりも、言語や社会の構造・体系から世界を理解しようとする' WHERE questionnaire_version = 1 AND code = 'logic' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '個々の行為よりも、人柄や徳の成熟を倫理の中心に置く', negative_description = '人柄よりも、個々の行為の正しさとその根拠を倫理の中心に置く' WHERE questionnaire_version = 1 AND code = 'ethics' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '美や価値を、世界の側に存在するものとして捉える', negative_description = '美や価値を、それを感じ取る人間の認識のあり方として捉える' WHERE questionnaire_version = 1 AND code = 'aesthetics' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '普遍的な基準や理性を疑い、多元的・相対的な見方を肯定する', negative_description = '理性や普遍的な基準によって世界を秩序づけられると考える' WHERE questionnaire_version = 1 AND code = 'postmodern' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '人間には原理的に知りえないことがあると考える', negative_description = '原理的には人間に知りえないことはないと考える' WHERE questionnaire_version = 1 AND code = 'agnostic' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '結果にかかわらず守るべき義務や規則があると考える', negative_description = '行為の善し悪しはその結果によって決まると考える' WHERE questionnaire_version = 1 AND code = 'deontology' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '自然科学や社会科学が哲学的問題の多くを解決していくと考える', negative_description = '哲学的問題には科学では扱いきれない人文的な領域が残ると考える' WHERE questionnaire_version = 1 AND code = 'science' AND positive_description IS NULL;
+UPDATE questionnaire_axes SET positive_description = '直観と論理が食い違うときは論理を信じる', negative_description = '論理よりも、経験されるままの直観や現れを重視する' WHERE questionnaire_version = 1 AND code = 'analytic' AND positive_description IS NULL;
+
+COMMIT;
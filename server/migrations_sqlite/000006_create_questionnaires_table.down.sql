@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS questionnaire_scale_labels;
+DROP TABLE IF EXISTS questionnaire_questions;
+DROP TABLE IF EXISTS questionnaires;
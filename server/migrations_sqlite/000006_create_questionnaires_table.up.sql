@@ -0,0 +1,30 @@
+-- 質問票テーブル
+-- 質問文・カテゴリ・選択肢ラベルをバージョン単位で管理する
+CREATE TABLE IF NOT EXISTS questionnaires (
+    version         INTEGER PRIMARY KEY,                      -- 質問票のバージョン（1始まり）
+    title           TEXT NOT NULL,
+    description     TEXT,
+    created_at      DATETIME NOT NULL DEFAULT (DATETIME('now')),
+    created_by      TEXT,
+    updated_at      DATETIME,
+    updated_by      TEXT
+);
+
+-- 質問テーブル
+-- positionは回答ベクトル上の位置（1始まり）
+CREATE TABLE IF NOT EXISTS questionnaire_questions (
+    id                      INTEGER PRIMARY KEY AUTOINCREMENT,
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    position                INTEGER NOT NULL,
+    category                TEXT NOT NULL,
+    text                    TEXT NOT NULL,
+    UNIQUE (questionnaire_version, position)
+);
+
+-- 選択肢ラベルテーブル（例: 2 => "まさにその通りだ"）
+CREATE TABLE IF NOT EXISTS questionnaire_scale_labels (
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    value                   INTEGER NOT NULL,
+    label                   TEXT NOT NULL,
+    PRIMARY KEY (questionnaire_version, value)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_answers_questionnaire_version;
+ALTER TABLE answers DROP COLUMN questionnaire_version;
@@ -0,0 +1,6 @@
+-- 回答がどのバージョンの質問票に対するものかを記録
+-- 既存の回答はすべてバージョン1として扱う
+ALTER TABLE answers ADD COLUMN questionnaire_version INTEGER NOT NULL DEFAULT 1;
+
+-- バージョン別の統計取得用
+CREATE INDEX IF NOT EXISTS idx_answers_questionnaire_version ON answers (questionnaire_version);
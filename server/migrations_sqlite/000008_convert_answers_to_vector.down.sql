@@ -0,0 +1,38 @@
+-- 固定カラム形式に戻す（ロールバック時）
+-- 注意: CHECK制約は復元されない
+ALTER TABLE answers ADD COLUMN answer_01 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_02 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_03 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_04 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_05 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_06 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_07 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_08 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_09 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_10 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_11 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_12 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_13 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_14 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_15 INTEGER;
+ALTER TABLE answers ADD COLUMN answer_16 INTEGER;
+
+UPDATE answers SET
+    answer_01 = json_extract(answer_vector, '$[0]'),
+    answer_02 = json_extract(answer_vector, '$[1]'),
+    answer_03 = json_extract(answer_vector, '$[2]'),
+    answer_04 = json_extract(answer_vector, '$[3]'),
+    answer_05 = json_extract(answer_vector, '$[4]'),
+    answer_06 = json_extract(answer_vector, '$[5]'),
+    answer_07 = json_extract(answer_vector, '$[6]'),
+    answer_08 = json_extract(answer_vector, '$[7]'),
+    answer_09 = json_extract(answer_vector, '$[8]'),
+    answer_10 = json_extract(answer_vector, '$[9]'),
+    answer_11 = json_extract(answer_vector, '$[10]'),
+    answer_12 = json_extract(answer_vector, '$[11]'),
+    answer_13 = json_extract(answer_vector, '$[12]'),
+    answer_14 = json_extract(answer_vector, '$[13]'),
+    answer_15 = json_extract(answer_vector, '$[14]'),
+    answer_16 = json_extract(answer_vector, '$[15]');
+
+ALTER TABLE answers DROP COLUMN answer_vector;
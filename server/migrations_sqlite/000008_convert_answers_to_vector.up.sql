@@ -0,0 +1,31 @@
+-- 回答を設問数に依存しない形式で保存する
+-- answer_01 ~ answer_16の固定カラムをJSON配列（例: "[1,0,-2,...]"）のanswer_vectorに置き換える
+-- 次元数は質問票（questionnaire_version）の設問数で決まる
+ALTER TABLE answers ADD COLUMN answer_vector TEXT NOT NULL DEFAULT '[]';
+
+-- 既存データを移行
+UPDATE answers
+SET answer_vector = json_array(
+    answer_01, answer_02, answer_03, answer_04,
+    answer_05, answer_06, answer_07, answer_08,
+    answer_09, answer_10, answer_11, answer_12,
+    answer_13, answer_14, answer_15, answer_16
+);
+
+-- 固定カラムを削除
+ALTER TABLE answers DROP COLUMN answer_01;
+ALTER TABLE answers DROP COLUMN answer_02;
+ALTER TABLE answers DROP COLUMN answer_03;
+ALTER TABLE answers DROP COLUMN answer_04;
+ALTER TABLE answers DROP COLUMN answer_05;
+ALTER TABLE answers DROP COLUMN answer_06;
+ALTER TABLE answers DROP COLUMN answer_07;
+ALTER TABLE answers DROP COLUMN answer_08;
+ALTER TABLE answers DROP COLUMN answer_09;
+ALTER TABLE answers DROP COLUMN answer_10;
+ALTER TABLE answers DROP COLUMN answer_11;
+ALTER TABLE answers DROP COLUMN answer_12;
+ALTER TABLE answers DROP COLUMN answer_13;
+ALTER TABLE answers DROP COLUMN answer_14;
+ALTER TABLE answers DROP COLUMN answer_15;
+ALTER TABLE answers DROP COLUMN answer_16;
@@ -0,0 +1,2 @@
+ALTER TABLE questionnaire_questions DROP COLUMN axis_code;
+DROP TABLE IF EXISTS questionnaire_axes;
@@ -0,0 +1,22 @@
+-- 軸定義テーブル
+-- ラベルの各文字を決める軸（集計する設問・両極の文字と名前・閾値）を質問票ごとに管理する
+-- 軸スコアが閾値以上なら正の極、未満なら負の極の文字になる
+CREATE TABLE IF NOT EXISTS questionnaire_axes (
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    code                    TEXT NOT NULL,                        -- 例: "logic"
+    position                INTEGER NOT NULL,                     -- ラベル内の並び順（1始まり）
+    label_group             TEXT NOT NULL CHECK (label_group IN ('main', 'sub')),
+    name                    TEXT NOT NULL,                        -- 例: "論理"
+    positive_letter         TEXT NOT NULL,                        -- 例: "N"
+    positive_name           TEXT NOT NULL,                        -- 例: "大きな物語志向"
+    negative_letter         TEXT NOT NULL,                        -- 例: "S"
+    negative_name           TEXT NOT NULL,                        -- 例: "構造志向"
+    threshold               REAL NOT NULL DEFAULT 0,
+    created_at              DATETIME NOT NULL DEFAULT (DATETIME('now')),
+    updated_at              DATETIME,
+    PRIMARY KEY (questionnaire_version, code),
+    UNIQUE (questionnaire_version, label_group, position)
+);
+
+-- 各設問がどの軸のスコアに加算されるか
+ALTER TABLE questionnaire_questions ADD COLUMN axis_code TEXT;
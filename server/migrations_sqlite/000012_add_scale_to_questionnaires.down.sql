@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_answers_validate_scale_update;
+DROP TRIGGER IF EXISTS trg_answers_validate_scale_insert;
+ALTER TABLE questionnaires DROP COLUMN scale_max;
+ALTER TABLE questionnaires DROP COLUMN scale_min;
@@ -0,0 +1,32 @@
+-- 質問票ごとの回答スケール（例: 5段階なら-2 ~ 2、スライダーなら0 ~ 10）
+-- 既存の質問票は従来どおり-2 ~ 2の5段階評価
+ALTER TABLE questionnaires ADD COLUMN scale_min INTEGER NOT NULL DEFAULT -2;
+ALTER TABLE questionnaires ADD COLUMN scale_max INTEGER NOT NULL DEFAULT 2 CHECK (scale_min < scale_max);
+
+-- 回答値が質問票のスケール内にあることを検証するトリガー
+-- （回答ベクトルはJSON配列のため、CHECK制約の代わりにトリガーで検証する）
+CREATE TRIGGER IF NOT EXISTS trg_answers_validate_scale_insert
+BEFORE INSERT ON answers
+BEGIN
+    SELECT RAISE(ABORT, 'answer value out of questionnaire scale')
+    WHERE EXISTS (
+        SELECT 1
+        FROM json_each(NEW.answer_vector) AS v, questionnaires q
+        WHERE q.version = NEW.questionnaire_version
+          AND v.value IS NOT NULL
+          AND (v.value < q.scale_min OR v.value > q.scale_max)
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_answers_validate_scale_update
+BEFORE UPDATE OF answer_vector, questionnaire_version ON answers
+BEGIN
+    SELECT RAISE(ABORT, 'answer value out of questionnaire scale')
+    WHERE EXISTS (
+        SELECT 1
+        FROM json_each(NEW.answer_vector) AS v, questionnaires q
+        WHERE q.version = NEW.questionnaire_version
+          AND v.value IS NOT NULL
+          AND (v.value < q.scale_min OR v.value > q.scale_max)
+    );
+END;
@@ -0,0 +1,15 @@
+-- 回答の下書きテーブル
+-- 質問に1問ずつ回答しながら途中保存し、最後に確定してanswersへ移す
+-- idは推測できないランダムなトークン（匿名ユーザーはこのトークンで下書きを再開する）
+CREATE TABLE IF NOT EXISTS answer_drafts (
+    id                      TEXT PRIMARY KEY,
+    user_id                 INTEGER REFERENCES "user"(id) ON DELETE CASCADE, -- 匿名の場合はNULL
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version),
+    answer_vector           TEXT NOT NULL,                                   -- 未回答の設問はnull
+    created_at              DATETIME NOT NULL DEFAULT (DATETIME('now')),
+    updated_at              DATETIME,
+    expires_at              DATETIME NOT NULL                                -- 更新のたびに延長される
+);
+
+-- 期限切れの下書きの削除を高速化
+CREATE INDEX IF NOT EXISTS idx_answer_drafts_expires_at ON answer_drafts (expires_at);
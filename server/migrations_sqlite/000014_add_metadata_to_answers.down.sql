@@ -0,0 +1,4 @@
+ALTER TABLE answers DROP COLUMN client_version;
+ALTER TABLE answers DROP COLUMN locale;
+ALTER TABLE answers DROP COLUMN duration_ms;
+ALTER TABLE answers DROP COLUMN response_times_ms;
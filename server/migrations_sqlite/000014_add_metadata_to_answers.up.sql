@@ -0,0 +1,6 @@
+-- 回答送信時の付帯情報（すべて任意）
+-- response_times_msは設問ごとの回答時間（ミリ秒）のJSON配列
+ALTER TABLE answers ADD COLUMN response_times_ms TEXT;
+ALTER TABLE answers ADD COLUMN duration_ms INTEGER CHECK (duration_ms >= 0);
+ALTER TABLE answers ADD COLUMN locale TEXT;
+ALTER TABLE answers ADD COLUMN client_version TEXT;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_answers_quality_flagged;
+ALTER TABLE answers DROP COLUMN quality_reasons;
+ALTER TABLE answers DROP COLUMN quality_flagged;
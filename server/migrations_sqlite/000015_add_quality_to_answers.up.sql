@@ -0,0 +1,14 @@
+-- 回答の品質判定（不注意な回答の検出）
+-- quality_reasonsは判定理由のJSON配列（例: ["long_string","too_fast"]）
+ALTER TABLE answers ADD COLUMN quality_flagged INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE answers ADD COLUMN quality_reasons TEXT;
+
+-- 統計から除外する回答の絞り込み用
+CREATE INDEX IF NOT EXISTS idx_answers_quality_flagged ON answers (questionnaire_version, quality_flagged);
+
+-- 判定前の既存の回答のうち、すべて同じ値の回答（4問以上回答）に印を付ける
+UPDATE answers
+SET quality_flagged = 1, quality_reasons = '["zero_variance"]'
+WHERE quality_reasons IS NULL
+  AND (SELECT COUNT(value) >= 4 AND COUNT(DISTINCT value) = 1
+       FROM json_each(answers.answer_vector));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS label_profiles;
+ALTER TABLE questionnaire_axes DROP COLUMN negative_description;
+ALTER TABLE questionnaire_axes DROP COLUMN positive_description;
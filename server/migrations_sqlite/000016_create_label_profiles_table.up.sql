@@ -0,0 +1,22 @@
+-- ラベルのタイプ解説テーブル
+-- メインラベル（例: "SVOP"）・サブラベル（例: "ADSL"）ごとの名前と解説を質問票ごとに管理する
+-- 解説が登録されていないラベルは、各極の名前から組み立てた名前のみを返す
+CREATE TABLE IF NOT EXISTS label_profiles (
+    questionnaire_version   INTEGER NOT NULL REFERENCES questionnaires(version) ON DELETE CASCADE,
+    label_group             TEXT NOT NULL CHECK (label_group IN ('main', 'sub')),
+    code                    TEXT NOT NULL,                        -- 例: "SVOP"
+    name                    TEXT NOT NULL,                        -- 例: "構造 × 徳 × 存在 × ポストモダン"
+    description             TEXT,                                 -- 傾向
+    strengths               TEXT,
+    weaknesses              TEXT,
+    relations               TEXT,                                 -- 他のタイプとの距離感
+    created_at              DATETIME NOT NULL DEFAULT (DATETIME('now')),
+    created_by              TEXT,
+    updated_at              DATETIME,
+    updated_by              TEXT,
+    PRIMARY KEY (questionnaire_version, label_group, code)
+);
+
+-- 各極の説明（ラベル解説で使用）
+ALTER TABLE questionnaire_axes ADD COLUMN positive_description TEXT;
+ALTER TABLE questionnaire_axes ADD COLUMN negative_description TEXT;
@@ -0,0 +1,2 @@
+-- 削除した一時テーブルは不要なため、元に戻す操作はない
+SELECT 1;
@@ -0,0 +1,3 @@
+-- 000003を再実行するたびに途中で失敗して残っていた一時テーブルを削除
+-- （マイグレーションの適用履歴を記録する前に作成したDBのみ。以降は失敗したマイグレーションをロールバックする）
+DROP TABLE IF EXISTS answers_new;
@@ -0,0 +1 @@
+ALTER TABLE questionnaires DROP COLUMN balanced_letter;
@@ -0,0 +1,5 @@
+-- バランス型の軸をラベルの文字"X"で表すか（例: "SXOP"）
+-- 既存の質問票は従来どおり極の文字だけでラベルを決める（過去の回答のラベルやラベル別の集計の意味を変えないため）
+-- falseの場合もバランス型の判定（AxisScore.balanced）は返す。"X"を使う場合は新しいバージョンの質問票で有効にし、
+-- そのバージョンのlabel_profilesに"X"を含むすべてのラベルの解説を用意する
+ALTER TABLE questionnaires ADD COLUMN balanced_letter INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,33 @@
+-- =========================================
+-- 質問票 バージョン1（16問・5段階評価）
+-- =========================================
+
+INSERT OR IGNORE INTO questionnaires (version, title, description, created_by)
+VALUES (1, '思想コンパス 16問', '論理・倫理・美・ポストモダンの4カテゴリと横断的な4問からなる標準版', 'system');
+
+INSERT OR IGNORE INTO questionnaire_questions (questionnaire_version, position, category, text)
+VALUES
+  (1,  1, '論理', '哲学は文学よりも数学に似ている'),
+  (1,  2, '論理', '理論に基づいた仮説は正しい傾向にある'),
+  (1,  3, '論理', '日常言語の意味はあいまいだ'),
+  (1,  4, '倫理', '善いことをする習慣よりも、善い人格を身に着けるべきだ'),
+  (1,  5, '倫理', '善い人は必ず幸福になるし、幸福な人は必ず善い人だ'),
+  (1,  6, '倫理', '他人を幸せにすることは善いことだ'),
+  (1,  7, '美', '美しさは美しいものに宿っている'),
+  (1,  8, '美', '美しいものにはそのものらしさがある'),
+  (1,  9, '美', '人工の美しさと自然の美しさに違いはない'),
+  (1, 10, 'ポストモダン', '真実は、時代、地域、その他によって無数に異なる'),
+  (1, 11, 'ポストモダン', '論理的に正しいことよりも、素朴な感情に従って行動すべきだ'),
+  (1, 12, 'ポストモダン', '個別具体的な場面にいる私とは違う、「本当の私」なんていない'),
+  (1, 13, '横断的', '人間に絶対に知りえないことはある'),
+  (1, 14, '横断的', '基本的に嘘をつくことは悪いことだ'),
+  (1, 15, '横断的', '自然科学や社会科学はいずれ多くの哲学的問題を解決するだろう'),
+  (1, 16, '横断的', '直観と論理なら、論理のほうを信じる');
+
+INSERT OR IGNORE INTO questionnaire_scale_labels (questionnaire_version, value, label)
+VALUES
+  (1,  2, 'まさにその通りだ'),
+  (1,  1, '少しそう思う'),
+  (1,  0, 'どちらとも言えない'),
+  (1, -1, '少し違うと思う'),
+  (1, -2, '絶対に違う');
@@ -0,0 +1,26 @@
+-- =========================================
+-- 質問票 バージョン1の軸定義
+-- メイン4軸（各3問の合計）とサブ4軸（各1問）
+-- =========================================
+
+INSERT OR IGNORE INTO questionnaire_axes
+(questionnaire_version, code, position, label_group, name, positive_letter, positive_name, negative_letter, negative_name)
+VALUES
+  (1, 'logic', 1, 'main', '論理', 'N', '大きな物語志向', 'S', '構造志向'),
+  (1, 'ethics', 2, 'main', '倫理', 'V', '徳論志向', 'A', '行為論志向'),
+  (1, 'aesthetics', 3, 'main', '美', 'O', '存在論志向', 'E', '認識論志向'),
+  (1, 'postmodern', 4, 'main', 'ポストモダン', 'P', 'ポストモダン志向', 'M', 'モダン志向'),
+  (1, 'agnostic', 1, 'sub', '不可知論 vs 可知論', 'A', '不可知論的', 'K', '可知論的'),
+  (1, 'deontology', 2, 'sub', '義務論 vs 帰結主義', 'D', '義務論的', 'C', '帰結主義的'),
+  (1, 'science', 3, 'sub', '科学的 vs 人文的', 'S', '科学的', 'H', '人文的'),
+  (1, 'analytic', 4, 'sub', '論理 vs 現象学', 'L', '論理重視（分析哲学的）', 'P', '現象学的');
+
+-- 設問と軸の対応（未設定の設問のみ）
+UPDATE questionnaire_questions SET axis_code = 'logic' WHERE questionnaire_version = 1 AND position IN (1, 2, 3) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'ethics' WHERE questionnaire_version = 1 AND position IN (4, 5, 6) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'aesthetics' WHERE questionnaire_version = 1 AND position IN (7, 8, 9) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'postmodern' WHERE questionnaire_version = 1 AND position IN (10, 11, 12) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'agnostic' WHERE questionnaire_version = 1 AND position IN (13) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'deontology' WHERE questionnaire_version = 1 AND position IN (14) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'science' WHERE questionnaire_version = 1 AND position IN (15) AND axis_code IS NULL;
+UPDATE questionnaire_questions SET axis_code = 'analytic' WHERE questionnaire_version = 1 AND position IN (16) AND axis_code IS NULL;